@@ -0,0 +1,9 @@
+data "datafy_autoscaling_rule_preview" "example" {
+  account_id = "79c406c5-7b64-43f2-ba76-9b01e74e3d90"
+  rule = jsonencode({
+    "in" : [
+      { "var" : "cluster_name" },
+      ["production-cluster"]
+    ]
+  })
+}
@@ -0,0 +1,7 @@
+data "datafy_caller_identity" "current" {}
+
+# Create accounts under the account of the provider's token
+resource "datafy_account" "team" {
+  name              = "team"
+  parent_account_id = data.datafy_caller_identity.current.account_id
+}
@@ -0,0 +1,30 @@
+variable "datafy_principal_arn" {
+  description = "The Datafy AWS principal, as listed in the Datafy permissions documentation."
+  type        = string
+}
+
+resource "datafy_account" "example" {
+  name = "my-account"
+}
+
+data "datafy_iam_policy_document" "example" {
+  account_id    = datafy_account.example.id
+  principal_arn = var.datafy_principal_arn
+  feature_set   = "autoscaling"
+}
+
+resource "aws_iam_role" "datafy" {
+  name               = "DatafyRole"
+  assume_role_policy = data.datafy_iam_policy_document.example.trust_policy_json
+}
+
+resource "aws_iam_role_policy" "datafy" {
+  name   = "DatafyPermissions"
+  role   = aws_iam_role.datafy.id
+  policy = data.datafy_iam_policy_document.example.permissions_policy_json
+}
+
+resource "datafy_role_arn" "example" {
+  account_id = datafy_account.example.id
+  arn        = aws_iam_role.datafy.arn
+}
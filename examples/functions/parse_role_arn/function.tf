@@ -0,0 +1,11 @@
+locals {
+  role = provider::datafy::parse_role_arn("arn:aws:iam::123456789012:role/service-role/DatafyRole")
+}
+
+output "role_account_id" {
+  value = local.role.account_id # "123456789012"
+}
+
+output "role_name" {
+  value = local.role.name # "DatafyRole"
+}
@@ -0,0 +1,24 @@
+resource "datafy_account_autoscaling_rules" "example" {
+  account_id = "79c406c5-7b64-43f2-ba76-9b01e74e3d90"
+
+  rules = [
+    {
+      active = true
+      rule = jsonencode({
+        "in" : [
+          { "var" : "cluster_name" },
+          ["production-cluster"]
+        ]
+      })
+    },
+    {
+      active = false
+      rule = jsonencode({
+        "some" : [
+          { "var" : "tags" },
+          { "in" : [{ "var" : "" }, ["env:staging"]] }
+        ]
+      })
+    },
+  ]
+}
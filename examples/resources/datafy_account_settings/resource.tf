@@ -0,0 +1,12 @@
+resource "datafy_account" "example" {
+  name = "my-account"
+}
+
+resource "datafy_account_settings" "example" {
+  account_id               = datafy_account.example.id
+  autoscaling_enabled      = true
+  shrink_enabled           = false
+  grow_threshold_percent   = 80
+  shrink_threshold_percent = 40
+  notification_email       = "storage-team@example.com"
+}
@@ -0,0 +1,15 @@
+resource "datafy_account" "example" {
+  name = "my-account"
+}
+
+resource "datafy_aws_account_association" "production" {
+  account_id     = datafy_account.example.id
+  aws_account_id = "123456789012"
+  role_arn       = "arn:aws:iam::123456789012:role/DatafyRole"
+}
+
+resource "datafy_aws_account_association" "staging" {
+  account_id     = datafy_account.example.id
+  aws_account_id = "210987654321"
+  role_arn       = "arn:aws:iam::210987654321:role/DatafyRole"
+}
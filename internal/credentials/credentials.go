@@ -0,0 +1,153 @@
+// Package credentials reads Datafy credentials from the shared credentials
+// file and from token files.
+//
+// The shared credentials file is an INI file with one section per named
+// profile:
+//
+//	[default]
+//	token = "..."
+//
+//	[staging]
+//	token    = "..."
+//	endpoint = "https://api.staging.datafy.io"
+//
+// Values may be quoted, so files written as TOML are read the same way.
+package credentials
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile is the profile used when none is selected.
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned by LoadProfile when the credentials file
+// has no section for the requested profile.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is a named set of credentials from the shared credentials file.
+type Profile struct {
+	Name     string
+	Token    string
+	Endpoint string
+}
+
+// DefaultFilePath returns the path of the shared credentials file,
+// ~/.datafy/credentials.
+func DefaultFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".datafy", "credentials"), nil
+}
+
+// LoadProfile reads the profile called name from the credentials file at
+// path. It returns an error wrapping fs.ErrNotExist if the file does not
+// exist, and one wrapping ErrProfileNotFound if the file has no such profile.
+func LoadProfile(path, name string) (*Profile, error) {
+	sections, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := sections[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, path)
+	}
+
+	return &Profile{
+		Name:     name,
+		Token:    values["token"],
+		Endpoint: values["endpoint"],
+	}, nil
+}
+
+// ReadTokenFile returns the token stored in the file at path, without
+// surrounding whitespace, as written by e.g. a mounted Kubernetes secret.
+func ReadTokenFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// parseFile parses the INI file at path into its sections' key/value pairs.
+func parseFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sections := map[string]map[string]string{}
+	var section map[string]string
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: invalid section header %q", path, lineNo, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			section = sections[name]
+			if section == nil {
+				section = map[string]string{}
+				sections[name] = section
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value, got %q", path, lineNo, line)
+		}
+		if section == nil {
+			return nil, fmt.Errorf("%s:%d: %q is not in a profile section", path, lineNo, strings.TrimSpace(key))
+		}
+
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+			unquoted, err := unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid value for %q: %w", path, lineNo, strings.TrimSpace(key), err)
+			}
+			value = unquoted
+		}
+		section[strings.TrimSpace(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// unquote removes the quotes around a double-quoted (escaped) or
+// single-quoted (literal) string.
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", errors.New("unterminated string")
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return strconv.Unquote(s)
+}
@@ -0,0 +1,86 @@
+package credentials
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := writeFile(t, `
+# Datafy credentials
+[default]
+token = default-token
+
+; staging organization
+[staging]
+token    = "staging-token"
+endpoint = 'https://api.staging.example.com'
+`)
+
+	p, err := LoadProfile(path, DefaultProfile)
+	require.NoError(t, err)
+	assert.Equal(t, Profile{Name: "default", Token: "default-token"}, *p)
+
+	p, err = LoadProfile(path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, Profile{Name: "staging", Token: "staging-token", Endpoint: "https://api.staging.example.com"}, *p)
+}
+
+func TestLoadProfile_notFound(t *testing.T) {
+	path := writeFile(t, "[default]\ntoken = default-token\n")
+
+	_, err := LoadProfile(path, "prod")
+	assert.True(t, errors.Is(err, ErrProfileNotFound), err)
+
+	_, err = LoadProfile(filepath.Join(t.TempDir(), "missing"), DefaultProfile)
+	assert.True(t, errors.Is(err, fs.ErrNotExist), err)
+}
+
+func TestLoadProfile_invalid(t *testing.T) {
+	invalid := []string{
+		"token = outside-section\n",
+		"[default\ntoken = x\n",
+		"[default]\ntoken\n",
+		"[default]\ntoken = \"unterminated\n",
+		"[default]\ntoken = 'unterminated\n",
+	}
+
+	for _, content := range invalid {
+		_, err := LoadProfile(writeFile(t, content), DefaultProfile)
+		assert.Error(t, err, content)
+	}
+}
+
+func TestReadTokenFile(t *testing.T) {
+	token, err := ReadTokenFile(writeFile(t, "mounted-token\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "mounted-token", token)
+
+	_, err = ReadTokenFile(writeFile(t, " \n"))
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = ReadTokenFile(filepath.Join(t.TempDir(), "missing"))
+	assert.True(t, errors.Is(err, fs.ErrNotExist), err)
+}
+
+func TestDefaultFilePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, err := DefaultFilePath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".datafy", "credentials"), path)
+}
@@ -8,6 +8,11 @@ import (
 
 type CreateAccountRequest struct {
 	AccountName string
+	// ParentAccountId defaults to the account of the caller's token.
+	ParentAccountId    string
+	Description        string
+	Labels             map[string]string
+	DeletionProtection bool
 }
 
 type CreateAccountResponse struct {
@@ -17,6 +22,10 @@ type CreateAccountResponse struct {
 type UpdateAccountRequest struct {
 	AccountId   string
 	AccountName string
+	Description string
+	// Labels replaces all labels of the account.
+	Labels             map[string]string
+	DeletionProtection bool
 }
 
 type UpdateAccountResponse struct {
@@ -31,6 +40,16 @@ type GetAccountResponse struct {
 	Account Account
 }
 
+type ListAccountsRequest struct {
+	// Labels, if set, limits the result to accounts that have all of the
+	// given labels.
+	Labels map[string]string
+}
+
+type ListAccountsResponse struct {
+	Accounts []Account
+}
+
 type DeleteAccountRequest struct {
 	AccountId string
 }
@@ -38,16 +57,44 @@ type DeleteAccountRequest struct {
 type DeleteAccountResponse struct {
 }
 
+// Account statuses reported by the API. Accounts are provisioned and removed
+// asynchronously; an empty status means the API does not report it and the
+// account is ready as soon as it can be read.
+const (
+	AccountStatusCreating = "creating"
+	AccountStatusActive   = "active"
+	AccountStatusDeleting = "deleting"
+)
+
 type Account struct {
 	AccountId       string `json:"accountId"`
 	AccountName     string `json:"accountName"`
 	ParentAccountId string `json:"parentAccountId"`
+	ExternalId      string `json:"externalId"`
+	Description     string `json:"description,omitempty"`
+	// Labels are free-form key/value pairs, e.g. cost center or owning team.
+	Labels map[string]string `json:"labels,omitempty"`
+	// DeletionProtection is nil if the API does not enforce deletion
+	// protection, in which case the provider enforces it.
+	DeletionProtection *bool  `json:"deletionProtection,omitempty"`
+	Status             string `json:"status,omitempty"`
 }
 
 func (c *Client) CreateAccount(ctx context.Context, req *CreateAccountRequest) (*CreateAccountResponse, error) {
-	resp, err := c.callAPI(ctx, http.MethodPost, "/api/v1/accounts", map[string]interface{}{
-		"name": req.AccountName,
-	})
+	body := map[string]interface{}{
+		"name":               req.AccountName,
+		"deletionProtection": req.DeletionProtection,
+	}
+	if req.ParentAccountId != "" {
+		body["parentAccountId"] = req.ParentAccountId
+	}
+	if req.Description != "" {
+		body["description"] = req.Description
+	}
+	if len(req.Labels) > 0 {
+		body["labels"] = req.Labels
+	}
+	resp, err := c.callAPI(ctx, http.MethodPost, "/api/v1/accounts", body)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +115,15 @@ func (c *Client) CreateAccount(ctx context.Context, req *CreateAccountRequest) (
 }
 
 func (c *Client) UpdateAccount(ctx context.Context, req *UpdateAccountRequest) (*UpdateAccountResponse, error) {
+	labels := req.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
 	resp, err := c.callAPI(ctx, http.MethodPut, "/api/v1/accounts/"+req.AccountId, map[string]interface{}{
-		"name": req.AccountName,
+		"name":               req.AccountName,
+		"description":        req.Description,
+		"labels":             labels,
+		"deletionProtection": req.DeletionProtection,
 	})
 	if err != nil {
 		return nil, err
@@ -111,6 +165,45 @@ func (c *Client) GetAccount(ctx context.Context, req *GetAccountRequest) (*GetAc
 	}, nil
 }
 
+// ListAccounts returns the accounts the caller's token can access.
+func (c *Client) ListAccounts(ctx context.Context, req *ListAccountsRequest) (*ListAccountsResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, "/api/v1/accounts", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var accounts []Account
+	if err := json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
+		return nil, err
+	}
+
+	matching := make([]Account, 0, len(accounts))
+	for _, account := range accounts {
+		if account.HasLabels(req.Labels) {
+			matching = append(matching, account)
+		}
+	}
+
+	return &ListAccountsResponse{
+		Accounts: matching,
+	}, nil
+}
+
+// HasLabels reports whether the account has all of the given labels.
+func (a Account) HasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if value, ok := a.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Client) DeleteAccount(ctx context.Context, req *DeleteAccountRequest) (*DeleteAccountResponse, error) {
 	resp, err := c.callAPI(ctx, http.MethodDelete, "/api/v1/accounts/"+req.AccountId, nil)
 	if err != nil {
@@ -118,7 +211,8 @@ func (c *Client) DeleteAccount(ctx context.Context, req *DeleteAccountRequest) (
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	// The account is removed asynchronously once the request is accepted.
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
 		return nil, toError(resp)
 	}
 
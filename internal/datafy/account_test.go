@@ -11,7 +11,9 @@ import (
 )
 
 func TestCreateAccount(t *testing.T) {
-	expected := Account{AccountId: "acc-123", AccountName: "my-account", ParentAccountId: "parent-001"}
+	deletionProtection := true
+	expected := Account{AccountId: "acc-123", AccountName: "my-account", ParentAccountId: "parent-001", ExternalId: "ext-456", DeletionProtection: &deletionProtection}
+	var gotBody map[string]interface{}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -21,6 +23,7 @@ func TestCreateAccount(t *testing.T) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		_ = json.NewEncoder(w).Encode(expected)
@@ -28,14 +31,57 @@ func TestCreateAccount(t *testing.T) {
 	defer ts.Close()
 
 	c := NewClient("dummy", ts.URL)
-	out, err := c.CreateAccount(context.Background(), &CreateAccountRequest{AccountName: expected.AccountName})
+	out, err := c.CreateAccount(context.Background(), &CreateAccountRequest{AccountName: expected.AccountName, DeletionProtection: true})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expected, out.Account)
+	assert.Equal(t, map[string]interface{}{"name": "my-account", "deletionProtection": true}, gotBody)
+}
+
+func TestCreateAccount_parentAccount(t *testing.T) {
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Account{AccountId: "acc-123", AccountName: "team", ParentAccountId: "bu-001"})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.CreateAccount(context.Background(), &CreateAccountRequest{AccountName: "team", ParentAccountId: "bu-001"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bu-001", out.Account.ParentAccountId)
+	assert.Equal(t, "bu-001", gotBody["parentAccountId"])
+}
+
+func TestCreateAccount_labels(t *testing.T) {
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Account{AccountId: "acc-123", AccountName: "team", Description: "Checkout team", Labels: map[string]string{"team": "checkout"}})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.CreateAccount(context.Background(), &CreateAccountRequest{
+		AccountName: "team",
+		Description: "Checkout team",
+		Labels:      map[string]string{"team": "checkout"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Checkout team", out.Account.Description)
+	assert.Equal(t, map[string]string{"team": "checkout"}, out.Account.Labels)
+	assert.Equal(t, "Checkout team", gotBody["description"])
+	assert.Equal(t, map[string]interface{}{"team": "checkout"}, gotBody["labels"])
 }
 
 func TestGetAccount(t *testing.T) {
-	expected := Account{AccountId: "acc-123", AccountName: "my-account", ParentAccountId: "parent-001"}
+	expected := Account{AccountId: "acc-123", AccountName: "my-account", ParentAccountId: "parent-001", ExternalId: "ext-456"}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -82,6 +128,56 @@ func TestUpdateAccount(t *testing.T) {
 	assert.Equal(t, expected, out.Account)
 }
 
+func TestUpdateAccount_clearLabels(t *testing.T) {
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(Account{AccountId: "acc-123", AccountName: "team"})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.UpdateAccount(context.Background(), &UpdateAccountRequest{AccountId: "acc-123", AccountName: "team"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{}, gotBody["labels"])
+	assert.Equal(t, "", gotBody["description"])
+}
+
+func TestListAccounts(t *testing.T) {
+	accounts := []Account{
+		{AccountId: "acc-1", AccountName: "checkout-prod", Labels: map[string]string{"team": "checkout", "env": "prod"}},
+		{AccountId: "acc-2", AccountName: "checkout-dev", Labels: map[string]string{"team": "checkout", "env": "dev"}},
+		{AccountId: "acc-3", AccountName: "unlabelled"},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(accounts)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+
+	out, err := c.ListAccounts(context.Background(), &ListAccountsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, accounts, out.Accounts)
+
+	out, err = c.ListAccounts(context.Background(), &ListAccountsRequest{Labels: map[string]string{"team": "checkout", "env": "prod"}})
+	assert.NoError(t, err)
+	assert.Equal(t, accounts[:1], out.Accounts)
+}
+
 func TestDeleteAccount(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -102,3 +198,27 @@ func TestDeleteAccount(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, out)
 }
+
+func TestDeleteAccount_accepted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.DeleteAccount(context.Background(), &DeleteAccountRequest{AccountId: "acc-123"})
+
+	assert.NoError(t, err)
+}
+
+func TestGetAccount_notFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.GetAccount(context.Background(), &GetAccountRequest{AccountId: "acc-123"})
+
+	assert.True(t, IsNotFound(err))
+}
@@ -0,0 +1,113 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ListAwsAccountAssociationsRequest struct {
+	AccountId string
+}
+
+type ListAwsAccountAssociationsResponse struct {
+	AwsAccountAssociations []AwsAccountAssociation
+}
+
+type CreateAwsAccountAssociationRequest struct {
+	AccountId      string
+	AwsAccountId   string
+	RoleArn        string
+	ExternalId     string
+	SkipValidation bool
+}
+
+type CreateAwsAccountAssociationResponse struct {
+	AwsAccountAssociation AwsAccountAssociation
+}
+
+type DeleteAwsAccountAssociationRequest struct {
+	AccountId    string
+	AwsAccountId string
+}
+
+type DeleteAwsAccountAssociationResponse struct {
+}
+
+// AwsAccountAssociation links a Datafy account to one of the AWS accounts it
+// spans, through an IAM role in that AWS account.
+type AwsAccountAssociation struct {
+	AwsAccountId string `json:"awsAccountId"`
+	RoleArn      string `json:"roleArn"`
+	ExternalId   string `json:"externalId"`
+	RoleValidation
+}
+
+func (c *Client) ListAwsAccountAssociations(ctx context.Context, req *ListAwsAccountAssociationsRequest) (*ListAwsAccountAssociationsResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/accounts/%s/aws-accounts", req.AccountId), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var associations []AwsAccountAssociation
+	if err := json.NewDecoder(resp.Body).Decode(&associations); err != nil {
+		return nil, err
+	}
+
+	return &ListAwsAccountAssociationsResponse{
+		AwsAccountAssociations: associations,
+	}, nil
+}
+
+// CreateAwsAccountAssociation creates the association, or replaces the role
+// of an existing association for the same AWS account.
+func (c *Client) CreateAwsAccountAssociation(ctx context.Context, req *CreateAwsAccountAssociationRequest) (*CreateAwsAccountAssociationResponse, error) {
+	body := map[string]interface{}{
+		"awsAccountId": req.AwsAccountId,
+		"roleArn":      req.RoleArn,
+	}
+	if req.ExternalId != "" {
+		body["externalId"] = req.ExternalId
+	}
+	if req.SkipValidation {
+		body["skipValidation"] = true
+	}
+	resp, err := c.callAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/aws-accounts", req.AccountId), body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var association AwsAccountAssociation
+	if err := json.NewDecoder(resp.Body).Decode(&association); err != nil {
+		return nil, err
+	}
+
+	return &CreateAwsAccountAssociationResponse{
+		AwsAccountAssociation: association,
+	}, nil
+}
+
+func (c *Client) DeleteAwsAccountAssociation(ctx context.Context, req *DeleteAwsAccountAssociationRequest) (*DeleteAwsAccountAssociationResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/accounts/%s/aws-accounts/%s", req.AccountId, req.AwsAccountId), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, toError(resp)
+	}
+
+	return &DeleteAwsAccountAssociationResponse{}, nil
+}
@@ -0,0 +1,115 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAwsAccountAssociations(t *testing.T) {
+	expected := []AwsAccountAssociation{
+		{
+			AwsAccountId:   "123456789012",
+			RoleArn:        "arn:aws:iam::123456789012:role/datafy",
+			ExternalId:     "ext-456",
+			RoleValidation: RoleValidation{ValidationStatus: RoleValidationStatusValid},
+		},
+		{
+			AwsAccountId:   "210987654321",
+			RoleArn:        "arn:aws:iam::210987654321:role/datafy",
+			ExternalId:     "ext-456",
+			RoleValidation: RoleValidation{ValidationStatus: RoleValidationStatusSkipped},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/aws-accounts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.ListAwsAccountAssociations(context.Background(), &ListAwsAccountAssociationsRequest{AccountId: "acc-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.AwsAccountAssociations)
+}
+
+func TestCreateAwsAccountAssociation(t *testing.T) {
+	expected := AwsAccountAssociation{
+		AwsAccountId:   "123456789012",
+		RoleArn:        "arn:aws:iam::123456789012:role/datafy",
+		ExternalId:     "ext-456",
+		RoleValidation: RoleValidation{ValidationStatus: RoleValidationStatusValid},
+	}
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/aws-accounts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.CreateAwsAccountAssociation(context.Background(), &CreateAwsAccountAssociationRequest{
+		AccountId:      "acc-123",
+		AwsAccountId:   expected.AwsAccountId,
+		RoleArn:        expected.RoleArn,
+		ExternalId:     expected.ExternalId,
+		SkipValidation: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.AwsAccountAssociation)
+	assert.Equal(t, map[string]interface{}{
+		"awsAccountId":   "123456789012",
+		"roleArn":        "arn:aws:iam::123456789012:role/datafy",
+		"externalId":     "ext-456",
+		"skipValidation": true,
+	}, gotBody)
+}
+
+func TestDeleteAwsAccountAssociation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/aws-accounts/123456789012" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.DeleteAwsAccountAssociation(context.Background(), &DeleteAwsAccountAssociationRequest{
+		AccountId:    "acc-123",
+		AwsAccountId: "123456789012",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, out)
+}
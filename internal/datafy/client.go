@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,51 +14,155 @@ import (
 )
 
 type Client struct {
-	token    string
-	endpoint string
+	tokenSource TokenSource
+	endpoint    string
 
 	httpClient *http.Client
 }
 
+// TokenSource supplies the token the client authenticates with.
+type TokenSource interface {
+	// Token returns a token that is valid for at least the next request.
+	Token(ctx context.Context) (string, error)
+	// Invalidate discards token after the API rejected it, so that the next
+	// call to Token returns a new one if possible.
+	Invalidate(token string)
+}
+
+// StaticToken is a TokenSource for a token that cannot be refreshed.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) { return string(t), nil }
+
+func (t StaticToken) Invalidate(string) {}
+
 func NewClient(token, endpoint string) *Client {
+	return NewClientWithTokenSource(StaticToken(token), endpoint, nil)
+}
+
+// NewClientWithTokenSource returns a client that authenticates with the
+// tokens returned by ts. endpoint must be normalized by ParseEndpoint, as API
+// paths are appended to it. If httpClient is nil, a client with the default
+// transport is used.
+func NewClientWithTokenSource(ts TokenSource, endpoint string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = NewHTTPClient(nil)
+	}
+
 	return &Client{
-		token:    token,
-		endpoint: endpoint,
+		tokenSource: ts,
+		endpoint:    endpoint,
+		httpClient:  httpClient,
+	}
+}
 
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+// NewHTTPClient returns an HTTP client suitable for calls to the Datafy API
+// that sends requests with transport, or the default transport if nil.
+func NewHTTPClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Second,
 	}
 }
 
 func (c *Client) callAPI(ctx context.Context, method, path string, body map[string]interface{}) (*http.Response, error) {
-	var reqBody io.Reader
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.endpoint, path), reqBody)
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.do(ctx, method, path, jsonData, token)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// The token may have been revoked or expired early. Retry once if the
+	// token source has a new one.
+	c.tokenSource.Invalidate(token)
+	newToken, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		res.Body.Close()
+		return nil, fmt.Errorf("refreshing rejected token: %w", err)
+	}
+	if newToken == token {
+		return res, nil
+	}
+	res.Body.Close()
+
+	return c.do(ctx, method, path, jsonData, newToken)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, jsonData []byte, token string) (*http.Response, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("User-Agent", fmt.Sprintf("terraform-provider-datafy/%s (datafy.io)", version.ProviderVersion))
 
 	return c.httpClient.Do(req)
 }
 
+// APIError is returned when the Datafy API responds with an unexpected status
+// code.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// MissingPermissions lists the IAM actions a role failed validation
+	// for, if the API reported them.
+	MissingPermissions []string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status code %d: %s", e.StatusCode, e.Message)
+}
+
+// IsRoleValidationError reports whether err is the response to a role ARN
+// that failed permission validation, e.g. because a newly created IAM role is
+// not assumable yet.
+func IsRoleValidationError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsNotFound reports whether err is the response to a request for an object
+// that does not exist.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 func toError(res *http.Response) error {
 	var errMessage struct {
-		Message string `json:"message,omitempty"`
+		Message            string   `json:"message,omitempty"`
+		MissingPermissions []string `json:"missingPermissions,omitempty"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&errMessage); err != nil {
-		return err
+		// Not every error response has a JSON body, e.g. a bare 404.
+		return &APIError{
+			StatusCode: res.StatusCode,
+			Message:    http.StatusText(res.StatusCode),
+		}
 	}
 
-	return fmt.Errorf("status code %d: %s", res.StatusCode, errMessage.Message)
+	return &APIError{
+		StatusCode:         res.StatusCode,
+		Message:            errMessage.Message,
+		MissingPermissions: errMessage.MissingPermissions,
+	}
 }
@@ -0,0 +1,74 @@
+package datafy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// ClientCredentials is a TokenSource that obtains short-lived access tokens
+// with the OAuth2 client credentials grant. Tokens are cached until shortly
+// before they expire, or until the API rejects them.
+type ClientCredentials struct {
+	config *clientcredentials.Config
+	// ctx carries the HTTP client used to call the token endpoint.
+	ctx context.Context
+
+	mu     sync.Mutex
+	source oauth2.TokenSource
+	token  string
+}
+
+var _ TokenSource = &ClientCredentials{}
+
+// NewClientCredentials returns a TokenSource that exchanges clientID and
+// clientSecret for access tokens at tokenURL. If httpClient is nil, a client
+// with the default transport is used.
+func NewClientCredentials(clientID, clientSecret, tokenURL string, httpClient *http.Client) *ClientCredentials {
+	if httpClient == nil {
+		httpClient = NewHTTPClient(nil)
+	}
+
+	return &ClientCredentials{
+		config: &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     tokenURL,
+		},
+		ctx: context.WithValue(context.Background(), oauth2.HTTPClient, httpClient),
+	}
+}
+
+func (c *ClientCredentials) Token(context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The token source returned by the config caches the token and fetches
+	// a new one when it is about to expire.
+	if c.source == nil {
+		c.source = c.config.TokenSource(c.ctx)
+	}
+
+	token, err := c.source.Token()
+	if err != nil {
+		return "", fmt.Errorf("fetching OAuth2 access token from %s: %w", c.config.TokenURL, err)
+	}
+
+	c.token = token.AccessToken
+	return c.token, nil
+}
+
+func (c *ClientCredentials) Invalidate(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop the cached token along with its token source.
+	if c.token == token {
+		c.source = nil
+		c.token = ""
+	}
+}
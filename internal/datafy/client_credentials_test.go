@@ -0,0 +1,131 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenEndpoint returns a stub OAuth2 token endpoint that issues
+// access-1, access-2, ... with the given lifetime to client-id/client-secret,
+// and a pointer to the number of tokens issued.
+func newTokenEndpoint(t *testing.T, expiresIn int) (*httptest.Server, *int) {
+	var issued int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		id, secret, ok := r.BasicAuth()
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "unsupported_grant_type"})
+			return
+		}
+		if !ok || id != "client-id" || secret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+
+		issued++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": fmt.Sprintf("access-%d", issued),
+			"token_type":   "bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &issued
+}
+
+func TestClientCredentials_cached(t *testing.T) {
+	ts, issued := newTokenEndpoint(t, 3600)
+	c := NewClientCredentials("client-id", "client-secret", ts.URL+"/oauth/token", nil)
+
+	for i := 0; i < 3; i++ {
+		token, err := c.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "access-1", token)
+	}
+	assert.Equal(t, 1, *issued)
+}
+
+func TestClientCredentials_refreshBeforeExpiry(t *testing.T) {
+	// A token that expires within oauth2's expiry margin is never reused.
+	ts, issued := newTokenEndpoint(t, 5)
+	c := NewClientCredentials("client-id", "client-secret", ts.URL+"/oauth/token", nil)
+
+	for i := 1; i <= 2; i++ {
+		token, err := c.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("access-%d", i), token)
+	}
+	assert.Equal(t, 2, *issued)
+}
+
+func TestClientCredentials_invalidate(t *testing.T) {
+	ts, issued := newTokenEndpoint(t, 3600)
+	c := NewClientCredentials("client-id", "client-secret", ts.URL+"/oauth/token", nil)
+
+	_, err := c.Token(context.Background())
+	require.NoError(t, err)
+
+	// Invalidating a token other than the cached one is a no-op.
+	c.Invalidate("access-0")
+	token, err := c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token)
+
+	c.Invalidate("access-1")
+	token, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", token)
+	assert.Equal(t, 2, *issued)
+}
+
+func TestClientCredentials_invalidClient(t *testing.T) {
+	ts, issued := newTokenEndpoint(t, 3600)
+	c := NewClientCredentials("client-id", "wrong-secret", ts.URL+"/oauth/token", nil)
+
+	_, err := c.Token(context.Background())
+	assert.ErrorContains(t, err, "fetching OAuth2 access token from "+ts.URL+"/oauth/token")
+	assert.ErrorContains(t, err, "invalid_client")
+	assert.Equal(t, 0, *issued)
+}
+
+func TestClient_clientCredentials(t *testing.T) {
+	tokenEndpoint, issued := newTokenEndpoint(t, 3600)
+
+	var gotAuthorization []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = append(gotAuthorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		// The first access token is revoked before it expires.
+		if r.Header.Get("Authorization") == "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "token revoked"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(CallerIdentity{AccountId: "acc-123"})
+	}))
+	defer ts.Close()
+
+	c := NewClientWithTokenSource(NewClientCredentials("client-id", "client-secret", tokenEndpoint.URL+"/oauth/token", nil), ts.URL, nil)
+	res, err := c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "acc-123", res.CallerIdentity.AccountId)
+	assert.Equal(t, []string{"Bearer access-1", "Bearer access-2"}, gotAuthorization)
+
+	_, err = c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, *issued)
+}
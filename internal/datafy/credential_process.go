@@ -0,0 +1,123 @@
+package datafy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credentialProcessRefreshWindow is how long before its expiry a token from
+// a credential process is refreshed, so that it does not expire mid-request.
+// Tokens that live for less than twice the window are refreshed halfway
+// through their lifetime instead, so that the helper is not run on every
+// request.
+const credentialProcessRefreshWindow = time.Minute
+
+// CredentialProcess is a TokenSource that obtains tokens by running an
+// external command, such as a helper that reads them from a secrets
+// manager. The command must print a JSON object to stdout:
+//
+//	{"token": "...", "expires_at": "2024-01-01T00:00:00Z"}
+//
+// expires_at is optional. The token is cached until shortly before it
+// expires, or until the API rejects it.
+type CredentialProcess struct {
+	command string
+
+	mu    sync.Mutex
+	token string
+	// refreshAt is when the token is refreshed, or zero if it does not
+	// expire.
+	refreshAt time.Time
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+var _ TokenSource = &CredentialProcess{}
+
+// NewCredentialProcess returns a TokenSource that runs command with the
+// system shell.
+func NewCredentialProcess(command string) *CredentialProcess {
+	return &CredentialProcess{
+		command: command,
+		now:     time.Now,
+	}
+}
+
+type credentialProcessOutput struct {
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (p *CredentialProcess) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && (p.refreshAt.IsZero() || p.now().Before(p.refreshAt)) {
+		return p.token, nil
+	}
+
+	output, err := p.run(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = output.Token
+	p.refreshAt = time.Time{}
+	if output.ExpiresAt != nil {
+		window := min(credentialProcessRefreshWindow, output.ExpiresAt.Sub(p.now())/2)
+		p.refreshAt = output.ExpiresAt.Add(-window)
+	}
+	return p.token, nil
+}
+
+func (p *CredentialProcess) Invalidate(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token == token {
+		p.token = ""
+	}
+}
+
+func (p *CredentialProcess) run(ctx context.Context) (*credentialProcessOutput, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", p.command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return nil, fmt.Errorf("credential_process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("credential_process failed: %w", err)
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("credential_process returned invalid output: %w", err)
+	}
+	if output.Token == "" {
+		return nil, errors.New("credential_process returned no token")
+	}
+	if output.ExpiresAt != nil && !output.ExpiresAt.After(p.now()) {
+		return nil, fmt.Errorf("credential_process returned a token that expired at %s", output.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return &output, nil
+}
@@ -0,0 +1,253 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeHelper writes a credential helper script that prints output, with
+// %d replaced by the number of times it has run, and returns the command
+// that runs it and a function that reports that number.
+func writeHelper(t *testing.T, output string) (string, func() int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential helper scripts need a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	countFile := filepath.Join(dir, "count")
+	script := fmt.Sprintf(`#!/bin/sh
+n=$(cat %[1]q 2>/dev/null || echo 0)
+n=$((n + 1))
+echo "$n" > %[1]q
+printf '%%s' %[2]q | sed "s/%%d/$n/g"
+`, countFile, output)
+	path := filepath.Join(dir, "helper.sh")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o700))
+
+	return path, func() int {
+		b, err := os.ReadFile(countFile)
+		if err != nil {
+			return 0
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(string(b)))
+		return n
+	}
+}
+
+func TestCredentialProcess_cached(t *testing.T) {
+	expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	command, runs := writeHelper(t, `{"token": "token-%d", "expires_at": "`+expires+`"}`)
+	p := NewCredentialProcess(command)
+
+	for i := 0; i < 3; i++ {
+		token, err := p.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	}
+	assert.Equal(t, 1, runs())
+}
+
+func TestCredentialProcess_noExpiry(t *testing.T) {
+	command, runs := writeHelper(t, `{"token": "token-%d"}`)
+	p := NewCredentialProcess(command)
+	p.now = func() time.Time { return time.Now().Add(24 * 365 * time.Hour) }
+
+	for i := 0; i < 2; i++ {
+		token, err := p.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	}
+	assert.Equal(t, 1, runs())
+}
+
+func TestCredentialProcess_refreshBeforeExpiry(t *testing.T) {
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	command, runs := writeHelper(t, `{"token": "token-%d", "expires_at": "`+expires.Format(time.RFC3339)+`"}`)
+	p := NewCredentialProcess(command)
+
+	token, err := p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Outside the refresh window the cached token is used.
+	p.now = func() time.Time { return expires.Add(-2 * credentialProcessRefreshWindow) }
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Within the refresh window the helper is run again.
+	p.now = func() time.Time { return expires.Add(-credentialProcessRefreshWindow / 2) }
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, runs())
+}
+
+func TestCredentialProcess_shortLived(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	expires := now.Add(credentialProcessRefreshWindow / 2)
+	command, runs := writeHelper(t, `{"token": "token-%d", "expires_at": "`+expires.Format(time.RFC3339)+`"}`)
+	p := NewCredentialProcess(command)
+	p.now = func() time.Time { return now }
+
+	// A token that expires within the refresh window is still cached for
+	// the first half of its lifetime.
+	for i := 0; i < 3; i++ {
+		token, err := p.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	}
+	assert.Equal(t, 1, runs())
+
+	p.now = func() time.Time { return expires.Add(-credentialProcessRefreshWindow / 8) }
+	token, err := p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, runs())
+}
+
+func TestCredentialProcess_invalidate(t *testing.T) {
+	command, runs := writeHelper(t, `{"token": "token-%d"}`)
+	p := NewCredentialProcess(command)
+
+	token, err := p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Invalidating a token other than the cached one is a no-op.
+	p.Invalidate("token-0")
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	p.Invalidate("token-1")
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, runs())
+}
+
+func TestCredentialProcess_errors(t *testing.T) {
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	cases := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{
+			name:     "exit status",
+			command:  "echo 'vault: permission denied' >&2; exit 3",
+			expected: "credential_process failed: exit status 3: vault: permission denied",
+		},
+		{
+			name:     "not found",
+			command:  "/nonexistent/datafy-helper",
+			expected: "credential_process failed: exit status 127",
+		},
+		{
+			name:     "invalid JSON",
+			command:  "echo not-json",
+			expected: "credential_process returned invalid output",
+		},
+		{
+			name:     "no token",
+			command:  `echo '{"expires_at": "2099-01-01T00:00:00Z"}'`,
+			expected: "credential_process returned no token",
+		},
+		{
+			name:     "expired",
+			command:  `echo '{"token": "t", "expires_at": "` + expired + `"}'`,
+			expected: "credential_process returned a token that expired at " + expired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" {
+				t.Skip("the commands need a POSIX shell")
+			}
+			_, err := NewCredentialProcess(tc.command).Token(context.Background())
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestClient_refreshAfterUnauthorized(t *testing.T) {
+	command, runs := writeHelper(t, `{"token": "token-%d"}`)
+
+	var gotAuthorization []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = append(gotAuthorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		// The first token is revoked before it expires.
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "token revoked"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(CallerIdentity{AccountId: "acc-123"})
+	}))
+	defer ts.Close()
+
+	c := NewClientWithTokenSource(NewCredentialProcess(command), ts.URL, nil)
+	res, err := c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "acc-123", res.CallerIdentity.AccountId)
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, gotAuthorization)
+	assert.Equal(t, 2, runs())
+
+	// The refreshed token is cached.
+	_, err = c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, runs())
+}
+
+func TestClient_unauthorizedStaticToken(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid token"})
+	}))
+	defer ts.Close()
+
+	// A static token cannot be refreshed, so the request is not retried.
+	c := NewClient("invalid", ts.URL)
+	_, err := c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+	assert.EqualError(t, err, "status code 401: invalid token")
+	assert.Equal(t, 1, requests)
+}
+
+func TestClient_refreshFailed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	// The helper succeeds once, then fails.
+	command := fmt.Sprintf(`if [ -e %[1]q ]; then echo 'session expired' >&2; exit 1; fi; touch %[1]q; echo '{"token": "token-1"}'`, marker)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	c := NewClientWithTokenSource(NewCredentialProcess(command), ts.URL, nil)
+	_, err := c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+	assert.ErrorContains(t, err, "refreshing rejected token: credential_process failed: exit status 1: session expired")
+}
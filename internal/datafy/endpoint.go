@@ -0,0 +1,67 @@
+package datafy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultEndpoint is the Datafy API endpoint used when none is configured.
+const DefaultEndpoint = "https://api.datafy.io"
+
+// RegionEndpoints maps each Datafy region to its API endpoint. Only regions
+// with a documented API endpoint are listed.
+var RegionEndpoints = map[string]string{
+	"us": DefaultEndpoint,
+}
+
+// Regions returns the names of the Datafy regions in RegionEndpoints, sorted.
+func Regions() []string {
+	regions := make([]string, 0, len(RegionEndpoints))
+	for region := range RegionEndpoints {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// ErrInsecureEndpoint is returned by ParseEndpoint for an http:// endpoint
+// when insecure endpoints are not allowed.
+var ErrInsecureEndpoint = errors.New("endpoint does not use HTTPS")
+
+// ParseEndpoint validates a Datafy API endpoint and returns it normalized,
+// with a lower case scheme and host and without trailing slashes, so that
+// API paths can be appended to it. Endpoints that do not use HTTPS are
+// refused unless allowInsecure is set.
+func ParseEndpoint(raw string, allowInsecure bool) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		return "", fmt.Errorf("missing scheme, expected a URL such as %s", DefaultEndpoint)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case u.Scheme != "https" && u.Scheme != "http":
+		return "", fmt.Errorf("unsupported scheme %q, expected https", u.Scheme)
+	case u.Host == "":
+		return "", errors.New("missing host")
+	case u.User != nil:
+		return "", errors.New("must not contain credentials")
+	case u.RawQuery != "" || u.Fragment != "" || u.ForceQuery:
+		return "", errors.New("must not have a query or fragment")
+	case u.Scheme == "http" && !allowInsecure:
+		return "", ErrInsecureEndpoint
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+
+	return u.String(), nil
+}
@@ -0,0 +1,46 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type GetCallerIdentityRequest struct {
+}
+
+type GetCallerIdentityResponse struct {
+	CallerIdentity CallerIdentity
+}
+
+// CallerIdentity describes the token the client authenticates with.
+type CallerIdentity struct {
+	AccountId       string   `json:"accountId"`
+	ParentAccountId string   `json:"parentAccountId"`
+	TokenId         string   `json:"tokenId"`
+	RoleIds         []string `json:"roleIds"`
+	// Expires is nil for tokens that do not expire.
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+func (c *Client) GetCallerIdentity(ctx context.Context, req *GetCallerIdentityRequest) (*GetCallerIdentityResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, "/api/v1/whoami", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var identity CallerIdentity
+	if err := json.NewDecoder(resp.Body).Decode(&identity); err != nil {
+		return nil, err
+	}
+
+	return &GetCallerIdentityResponse{
+		CallerIdentity: identity,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCallerIdentity(t *testing.T) {
+	expires := time.Now().Add(30 * time.Minute).UTC().Round(time.Second)
+	expected := CallerIdentity{
+		AccountId:       "acc-123",
+		ParentAccountId: "parent-001",
+		TokenId:         "tok-abc",
+		RoleIds:         []string{"admin"},
+		Expires:         &expires,
+	}
+	var gotAuthorization string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/whoami" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotAuthorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.CallerIdentity)
+	assert.Equal(t, "Bearer dummy", gotAuthorization)
+}
+
+func TestGetCallerIdentity_unauthorized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "token expired"})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.GetCallerIdentity(context.Background(), &GetCallerIdentityRequest{})
+
+	assert.EqualError(t, err, "status code 401: token expired")
+}
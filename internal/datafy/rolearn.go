@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type CreateAccountRoleArnRequest struct {
 	AccountId      string
 	Arn            string
+	ExternalId     string
 	SkipValidation bool
 }
 
@@ -28,6 +30,7 @@ type GetAccountRoleArnResponse struct {
 type UpdateAccountRoleArnRequest struct {
 	AccountId      string
 	Arn            string
+	ExternalId     string
 	SkipValidation bool
 }
 
@@ -42,14 +45,34 @@ type DeleteAccountRoleArnRequest struct {
 type DeleteAccountRoleArnResponse struct {
 }
 
+// Role ARN validation statuses reported by Datafy.
+const (
+	RoleValidationStatusValid   = "valid"
+	RoleValidationStatusInvalid = "invalid"
+	RoleValidationStatusSkipped = "skipped"
+)
+
+// RoleValidation is the outcome of the last validation Datafy ran against an
+// IAM role.
+type RoleValidation struct {
+	ValidationStatus   string     `json:"validationStatus"`
+	LastValidatedAt    *time.Time `json:"lastValidatedAt,omitempty"`
+	MissingPermissions []string   `json:"missingPermissions,omitempty"`
+}
+
 type AccountRoleArn struct {
-	RoleArn string `json:"roleArn"`
+	RoleArn    string `json:"roleArn"`
+	ExternalId string `json:"externalId"`
+	RoleValidation
 }
 
 func (c *Client) CreateAccountRoleArn(ctx context.Context, req *CreateAccountRoleArnRequest) (*CreateAccountRoleArnResponse, error) {
 	body := map[string]interface{}{
 		"roleArn": req.Arn,
 	}
+	if req.ExternalId != "" {
+		body["externalId"] = req.ExternalId
+	}
 	if req.SkipValidation {
 		body["skipValidation"] = true
 	}
@@ -98,6 +121,7 @@ func (c *Client) UpdateAccountRoleArn(ctx context.Context, req *UpdateAccountRol
 	res, err := c.CreateAccountRoleArn(ctx, &CreateAccountRoleArnRequest{
 		AccountId:      req.AccountId,
 		Arn:            req.Arn,
+		ExternalId:     req.ExternalId,
 		SkipValidation: req.SkipValidation,
 	})
 	if err != nil {
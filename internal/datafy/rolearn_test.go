@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateAccountRoleArn(t *testing.T) {
-	expected := AccountRoleArn{RoleArn: "arn:aws:iam::123456789012:role/test"}
+	expected := AccountRoleArn{RoleArn: "arn:aws:iam::123456789012:role/test", ExternalId: "ext-456"}
+	var gotBody map[string]interface{}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -21,6 +23,7 @@ func TestCreateAccountRoleArn(t *testing.T) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		_ = json.NewEncoder(w).Encode(expected)
@@ -29,16 +32,27 @@ func TestCreateAccountRoleArn(t *testing.T) {
 
 	c := NewClient("dummy", ts.URL)
 	out, err := c.CreateAccountRoleArn(context.Background(), &CreateAccountRoleArnRequest{
-		AccountId: "acc-123",
-		Arn:       expected.RoleArn,
+		AccountId:  "acc-123",
+		Arn:        expected.RoleArn,
+		ExternalId: expected.ExternalId,
 	})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expected, out.AccountRoleArn)
+	assert.Equal(t, "ext-456", gotBody["externalId"])
+	assert.NotContains(t, gotBody, "skipValidation")
 }
 
 func TestGetAccountRoleArn(t *testing.T) {
-	expected := AccountRoleArn{RoleArn: "arn:aws:iam::123456789012:role/test"}
+	lastValidatedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	expected := AccountRoleArn{
+		RoleArn: "arn:aws:iam::123456789012:role/test",
+		RoleValidation: RoleValidation{
+			ValidationStatus:   RoleValidationStatusInvalid,
+			LastValidatedAt:    &lastValidatedAt,
+			MissingPermissions: []string{"ec2:ModifyVolume"},
+		},
+	}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -106,3 +120,46 @@ func TestDeleteAccountRoleArn(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, out)
 }
+
+func TestCreateAccountRoleArn_validationError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"message":            "role is missing permissions",
+			"missingPermissions": []string{"ec2:ModifyVolume", "ec2:CreateTags"},
+		})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.CreateAccountRoleArn(context.Background(), &CreateAccountRoleArnRequest{
+		AccountId: "acc-123",
+		Arn:       "arn:aws:iam::123456789012:role/test",
+	})
+
+	assert.EqualError(t, err, "status code 422: role is missing permissions")
+	assert.True(t, IsRoleValidationError(err))
+
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, []string{"ec2:ModifyVolume", "ec2:CreateTags"}, apiErr.MissingPermissions)
+}
+
+func TestCreateAccountRoleArn_otherError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "forbidden"})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.CreateAccountRoleArn(context.Background(), &CreateAccountRoleArnRequest{
+		AccountId: "acc-123",
+		Arn:       "arn:aws:iam::123456789012:role/test",
+	})
+
+	assert.Error(t, err)
+	assert.False(t, IsRoleValidationError(err))
+}
@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type CreateAccountAutoscalingRuleRequest struct {
-	AccountId string          `json:"account_id"`
-	Active    bool            `json:"active"`
-	Rule      json.RawMessage `json:"rule"`
+	AccountId   string                   `json:"account_id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Priority    *int64                   `json:"priority,omitempty"`
+	Active      bool                     `json:"active"`
+	Schedule    *AutoscalingRuleSchedule `json:"schedule,omitempty"`
+	Rule        json.RawMessage          `json:"rule"`
 }
 
 type CreateAccountAutoscalingRuleResponse struct {
@@ -27,16 +32,31 @@ type GetAccountAutoscalingRuleResponse struct {
 }
 
 type UpdateAccountAutoscalingRuleRequest struct {
-	AccountId string          `json:"account_id"`
-	RuleId    string          `json:"rule_id"`
-	Active    bool            `json:"active"`
-	Rule      json.RawMessage `json:"rule"`
+	AccountId   string                   `json:"account_id"`
+	RuleId      string                   `json:"rule_id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Priority    *int64                   `json:"priority,omitempty"`
+	Active      bool                     `json:"active"`
+	Schedule    *AutoscalingRuleSchedule `json:"schedule,omitempty"`
+	// ClearSchedule removes the rule's schedule when Schedule is nil. Without
+	// it, a nil Schedule keeps the schedule the rule has.
+	ClearSchedule bool            `json:"-"`
+	Rule          json.RawMessage `json:"rule"`
 }
 
 type UpdateAccountAutoscalingRuleResponse struct {
 	AutoscalingRule AutoscalingRule
 }
 
+type ListAccountAutoscalingRulesRequest struct {
+	AccountId string
+}
+
+type ListAccountAutoscalingRulesResponse struct {
+	AutoscalingRules []AutoscalingRule
+}
+
 type DeleteAccountAutoscalingRuleRequest struct {
 	AccountId string
 	RuleId    string
@@ -45,18 +65,45 @@ type DeleteAccountAutoscalingRuleRequest struct {
 type DeleteAccountAutoscalingRuleResponse struct {
 }
 
+type PreviewAccountAutoscalingRuleRequest struct {
+	AccountId string
+	Rule      json.RawMessage
+}
+
+type PreviewAccountAutoscalingRuleResponse struct {
+	AutoscalingRulePreview AutoscalingRulePreview
+}
+
 type AutoscalingRule struct {
-	AccountId string          `json:"accountId"`
-	RuleId    string          `json:"ruleId"`
-	Active    bool            `json:"active"`
-	Rule      json.RawMessage `json:"rule"`
+	AccountId       string                   `json:"accountId"`
+	RuleId          string                   `json:"ruleId"`
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description"`
+	Priority        int64                    `json:"priority"`
+	Active          bool                     `json:"active"`
+	Schedule        *AutoscalingRuleSchedule `json:"schedule,omitempty"`
+	EffectiveActive bool                     `json:"effectiveActive"`
+	Rule            json.RawMessage          `json:"rule"`
+}
+
+// AutoscalingRuleSchedule restricts when an active rule is in force, either
+// through a recurring cron window or through a fixed time range.
+type AutoscalingRuleSchedule struct {
+	Cron            string     `json:"cron,omitempty"`
+	DurationMinutes int64      `json:"durationMinutes,omitempty"`
+	Timezone        string     `json:"timezone,omitempty"`
+	ActiveFrom      *time.Time `json:"activeFrom,omitempty"`
+	ActiveUntil     *time.Time `json:"activeUntil,omitempty"`
+}
+
+type AutoscalingRulePreview struct {
+	VolumeIds    []string `json:"volumeIds"`
+	InstanceIds  []string `json:"instanceIds"`
+	TotalSizeGiB int64    `json:"totalSizeGiB"`
 }
 
 func (c *Client) CreateAccountAutoscalingRule(ctx context.Context, req *CreateAccountAutoscalingRuleRequest) (*CreateAccountAutoscalingRuleResponse, error) {
-	resp, err := c.callAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules", req.AccountId), map[string]interface{}{
-		"active": req.Active,
-		"rule":   req.Rule,
-	})
+	resp, err := c.callAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules", req.AccountId), autoscalingRuleBody(req.Name, req.Description, req.Priority, req.Active, req.Schedule, req.Rule))
 	if err != nil {
 		return nil, err
 	}
@@ -98,10 +145,14 @@ func (c *Client) GetAccountAutoscalingRule(ctx context.Context, req *GetAccountA
 }
 
 func (c *Client) UpdateAccountAutoscalingRule(ctx context.Context, req *UpdateAccountAutoscalingRuleRequest) (*UpdateAccountAutoscalingRuleResponse, error) {
-	resp, err := c.callAPI(ctx, http.MethodPut, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules/%s", req.AccountId, req.RuleId), map[string]interface{}{
-		"active": req.Active,
-		"rule":   req.Rule,
-	})
+	body := autoscalingRuleBody(req.Name, req.Description, req.Priority, req.Active, req.Schedule, req.Rule)
+	// Without a schedule the API keeps the current one, so removing it takes
+	// an explicit null.
+	if req.Schedule == nil && req.ClearSchedule {
+		body["schedule"] = nil
+	}
+
+	resp, err := c.callAPI(ctx, http.MethodPut, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules/%s", req.AccountId, req.RuleId), body)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +172,27 @@ func (c *Client) UpdateAccountAutoscalingRule(ctx context.Context, req *UpdateAc
 	}, nil
 }
 
+func (c *Client) ListAccountAutoscalingRules(ctx context.Context, req *ListAccountAutoscalingRulesRequest) (*ListAccountAutoscalingRulesResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules", req.AccountId), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var autoscalingRules []AutoscalingRule
+	if err := json.NewDecoder(resp.Body).Decode(&autoscalingRules); err != nil {
+		return nil, err
+	}
+
+	return &ListAccountAutoscalingRulesResponse{
+		AutoscalingRules: autoscalingRules,
+	}, nil
+}
+
 func (c *Client) DeleteAccountAutoscalingRule(ctx context.Context, req *DeleteAccountAutoscalingRuleRequest) (*DeleteAccountAutoscalingRuleResponse, error) {
 	resp, err := c.callAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules/%s", req.AccountId, req.RuleId), nil)
 	if err != nil {
@@ -134,3 +206,44 @@ func (c *Client) DeleteAccountAutoscalingRule(ctx context.Context, req *DeleteAc
 
 	return &DeleteAccountAutoscalingRuleResponse{}, nil
 }
+
+// PreviewAccountAutoscalingRule evaluates a candidate rule against the volumes
+// of the account without persisting it.
+func (c *Client) PreviewAccountAutoscalingRule(ctx context.Context, req *PreviewAccountAutoscalingRuleRequest) (*PreviewAccountAutoscalingRuleResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/autoscaling/rules/preview", req.AccountId), map[string]interface{}{
+		"rule": req.Rule,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var autoscalingRulePreview AutoscalingRulePreview
+	if err := json.NewDecoder(resp.Body).Decode(&autoscalingRulePreview); err != nil {
+		return nil, err
+	}
+
+	return &PreviewAccountAutoscalingRuleResponse{
+		AutoscalingRulePreview: autoscalingRulePreview,
+	}, nil
+}
+
+func autoscalingRuleBody(name, description string, priority *int64, active bool, schedule *AutoscalingRuleSchedule, rule json.RawMessage) map[string]interface{} {
+	body := map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"active":      active,
+		"rule":        rule,
+	}
+	if priority != nil {
+		body["priority"] = *priority
+	}
+	if schedule != nil {
+		body["schedule"] = schedule
+	}
+	return body
+}
@@ -6,17 +6,22 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreateAccountAutoscalingRule(t *testing.T) {
 	expectedRule := AutoscalingRule{
-		AccountId: "acc-123",
-		RuleId:    "rule-abc",
-		Active:    true,
-		Rule:      json.RawMessage(`{"max":10,"min":1}`),
+		AccountId:   "acc-123",
+		RuleId:      "rule-abc",
+		Name:        "production",
+		Description: "Production clusters",
+		Priority:    10,
+		Active:      true,
+		Rule:        json.RawMessage(`{"max":10,"min":1}`),
 	}
+	var gotBody map[string]interface{}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -26,6 +31,70 @@ func TestCreateAccountAutoscalingRule(t *testing.T) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(expectedRule)
+	}))
+	defer ts.Close()
+
+	priority := expectedRule.Priority
+	c := NewClient("dummy", ts.URL)
+	out, err := c.CreateAccountAutoscalingRule(context.Background(), &CreateAccountAutoscalingRuleRequest{
+		AccountId:   expectedRule.AccountId,
+		Name:        expectedRule.Name,
+		Description: expectedRule.Description,
+		Priority:    &priority,
+		Active:      expectedRule.Active,
+		Rule:        expectedRule.Rule,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRule, out.AutoscalingRule)
+	assert.Equal(t, "production", gotBody["name"])
+	assert.Equal(t, "Production clusters", gotBody["description"])
+	assert.Equal(t, float64(10), gotBody["priority"])
+}
+
+func TestCreateAccountAutoscalingRule_noPriority(t *testing.T) {
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(AutoscalingRule{AccountId: "acc-123", RuleId: "rule-abc"})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.CreateAccountAutoscalingRule(context.Background(), &CreateAccountAutoscalingRuleRequest{
+		AccountId: "acc-123",
+		Active:    true,
+		Rule:      json.RawMessage(`{"max":10,"min":1}`),
+	})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, gotBody, "priority")
+}
+
+func TestCreateAccountAutoscalingRule_schedule(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectedRule := AutoscalingRule{
+		AccountId: "acc-123",
+		RuleId:    "rule-abc",
+		Active:    true,
+		Schedule: &AutoscalingRuleSchedule{
+			Cron:            "0 2 * * 6",
+			DurationMinutes: 240,
+			Timezone:        "Europe/Berlin",
+			ActiveFrom:      &from,
+		},
+		EffectiveActive: false,
+		Rule:            json.RawMessage(`{"max":10,"min":1}`),
+	}
+	var gotBody map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
 		_ = json.NewEncoder(w).Encode(expectedRule)
@@ -36,11 +105,13 @@ func TestCreateAccountAutoscalingRule(t *testing.T) {
 	out, err := c.CreateAccountAutoscalingRule(context.Background(), &CreateAccountAutoscalingRuleRequest{
 		AccountId: expectedRule.AccountId,
 		Active:    expectedRule.Active,
+		Schedule:  expectedRule.Schedule,
 		Rule:      expectedRule.Rule,
 	})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedRule, out.AutoscalingRule)
+	assert.JSONEq(t, `{"cron":"0 2 * * 6","durationMinutes":240,"timezone":"Europe/Berlin","activeFrom":"2026-01-01T00:00:00Z"}`, string(gotBody["schedule"]))
 }
 
 func TestGetAccountAutoscalingRule(t *testing.T) {
@@ -77,10 +148,13 @@ func TestGetAccountAutoscalingRule(t *testing.T) {
 
 func TestUpdateAccountAutoscalingRule(t *testing.T) {
 	expectedRule := AutoscalingRule{
-		AccountId: "acc-123",
-		RuleId:    "rule-abc",
-		Active:    true,
-		Rule:      json.RawMessage(`{"max":20,"min":2}`),
+		AccountId:   "acc-123",
+		RuleId:      "rule-abc",
+		Name:        "staging",
+		Description: "Staging clusters",
+		Priority:    20,
+		Active:      true,
+		Rule:        json.RawMessage(`{"max":20,"min":2}`),
 	}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
@@ -99,16 +173,94 @@ func TestUpdateAccountAutoscalingRule(t *testing.T) {
 
 	c := NewClient("dummy", ts.URL)
 	out, err := c.UpdateAccountAutoscalingRule(context.Background(), &UpdateAccountAutoscalingRuleRequest{
-		AccountId: expectedRule.AccountId,
-		RuleId:    expectedRule.RuleId,
-		Active:    expectedRule.Active,
-		Rule:      expectedRule.Rule,
+		AccountId:   expectedRule.AccountId,
+		RuleId:      expectedRule.RuleId,
+		Name:        expectedRule.Name,
+		Description: expectedRule.Description,
+		Priority:    &expectedRule.Priority,
+		Active:      expectedRule.Active,
+		Rule:        expectedRule.Rule,
 	})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedRule, out.AutoscalingRule)
 }
 
+func TestUpdateAccountAutoscalingRule_removeSchedule(t *testing.T) {
+	var gotBody map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(AutoscalingRule{AccountId: "acc-123", RuleId: "rule-abc"})
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	_, err := c.UpdateAccountAutoscalingRule(context.Background(), &UpdateAccountAutoscalingRuleRequest{
+		AccountId:     "acc-123",
+		RuleId:        "rule-abc",
+		Active:        true,
+		ClearSchedule: true,
+		Rule:          json.RawMessage(`{"max":10,"min":1}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, gotBody, "schedule")
+	assert.Equal(t, "null", string(gotBody["schedule"]))
+
+	// Without ClearSchedule the schedule is left alone.
+	gotBody = nil
+	_, err = c.UpdateAccountAutoscalingRule(context.Background(), &UpdateAccountAutoscalingRuleRequest{
+		AccountId: "acc-123",
+		RuleId:    "rule-abc",
+		Active:    true,
+		Rule:      json.RawMessage(`{"max":10,"min":1}`),
+	})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, gotBody, "schedule")
+}
+
+func TestListAccountAutoscalingRules(t *testing.T) {
+	expectedRules := []AutoscalingRule{
+		{
+			AccountId: "acc-123",
+			RuleId:    "rule-abc",
+			Active:    true,
+			Rule:      json.RawMessage(`{"max":10,"min":1}`),
+		},
+		{
+			AccountId: "acc-123",
+			RuleId:    "rule-def",
+			Active:    false,
+			Rule:      json.RawMessage(`{"max":20,"min":2}`),
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/autoscaling/rules" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expectedRules)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.ListAccountAutoscalingRules(context.Background(), &ListAccountAutoscalingRulesRequest{
+		AccountId: "acc-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRules, out.AutoscalingRules)
+}
+
 func TestDeleteAccountAutoscalingRule(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -132,3 +284,37 @@ func TestDeleteAccountAutoscalingRule(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, out)
 }
+
+func TestPreviewAccountAutoscalingRule(t *testing.T) {
+	expected := AutoscalingRulePreview{
+		VolumeIds:    []string{"vol-0a1b2c3d", "vol-4e5f6a7b"},
+		InstanceIds:  []string{"i-1234567890abcdef0"},
+		TotalSizeGiB: 300,
+	}
+	var gotBody map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/autoscaling/rules/preview" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.PreviewAccountAutoscalingRule(context.Background(), &PreviewAccountAutoscalingRuleRequest{
+		AccountId: "acc-123",
+		Rule:      json.RawMessage(`{"in":[{"var":"cluster_name"},["prod"]]}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.AutoscalingRulePreview)
+	assert.JSONEq(t, `{"in":[{"var":"cluster_name"},["prod"]]}`, string(gotBody["rule"]))
+}
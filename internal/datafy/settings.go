@@ -0,0 +1,117 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type GetAccountSettingsRequest struct {
+	AccountId string
+}
+
+type GetAccountSettingsResponse struct {
+	AccountSettings AccountSettings
+}
+
+type PutAccountSettingsRequest struct {
+	AccountId       string
+	AccountSettings AccountSettings
+}
+
+type PutAccountSettingsResponse struct {
+	AccountSettings AccountSettings
+}
+
+type ResetAccountSettingsRequest struct {
+	AccountId string
+}
+
+type ResetAccountSettingsResponse struct {
+}
+
+// AccountSettings are the volume lifecycle defaults of an account. Every
+// account has settings; they start out with the service defaults.
+type AccountSettings struct {
+	// AutoscalingEnabled turns autoscaling of the account's volumes on or
+	// off.
+	AutoscalingEnabled bool `json:"autoscalingEnabled"`
+	// ShrinkEnabled allows autoscaling to reduce volume size, not only grow
+	// it.
+	ShrinkEnabled bool `json:"shrinkEnabled"`
+	// GrowThresholdPercent is the disk utilization above which a volume is
+	// grown, unless a rule says otherwise.
+	GrowThresholdPercent int64 `json:"growThresholdPercent"`
+	// ShrinkThresholdPercent is the disk utilization below which a volume is
+	// shrunk, unless a rule says otherwise.
+	ShrinkThresholdPercent int64 `json:"shrinkThresholdPercent"`
+	// NotificationEmail receives notifications about scaling events. Empty
+	// to disable email notifications.
+	NotificationEmail string `json:"notificationEmail"`
+}
+
+func (c *Client) GetAccountSettings(ctx context.Context, req *GetAccountSettingsRequest) (*GetAccountSettingsResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/accounts/%s/settings", req.AccountId), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var settings AccountSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, err
+	}
+
+	return &GetAccountSettingsResponse{
+		AccountSettings: settings,
+	}, nil
+}
+
+// PutAccountSettings replaces all settings of the account.
+func (c *Client) PutAccountSettings(ctx context.Context, req *PutAccountSettingsRequest) (*PutAccountSettingsResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodPut, fmt.Sprintf("/api/v1/accounts/%s/settings", req.AccountId), map[string]interface{}{
+		"autoscalingEnabled":     req.AccountSettings.AutoscalingEnabled,
+		"shrinkEnabled":          req.AccountSettings.ShrinkEnabled,
+		"growThresholdPercent":   req.AccountSettings.GrowThresholdPercent,
+		"shrinkThresholdPercent": req.AccountSettings.ShrinkThresholdPercent,
+		"notificationEmail":      req.AccountSettings.NotificationEmail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var settings AccountSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+		return nil, err
+	}
+
+	return &PutAccountSettingsResponse{
+		AccountSettings: settings,
+	}, nil
+}
+
+// ResetAccountSettings restores the service defaults of all settings of the
+// account.
+func (c *Client) ResetAccountSettings(ctx context.Context, req *ResetAccountSettingsRequest) (*ResetAccountSettingsResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/accounts/%s/settings", req.AccountId), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return nil, toError(resp)
+	}
+
+	return &ResetAccountSettingsResponse{}, nil
+}
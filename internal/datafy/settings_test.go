@@ -0,0 +1,100 @@
+package datafy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAccountSettings(t *testing.T) {
+	expected := AccountSettings{
+		AutoscalingEnabled:     true,
+		ShrinkEnabled:          false,
+		GrowThresholdPercent:   80,
+		ShrinkThresholdPercent: 40,
+		NotificationEmail:      "storage@example.com",
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.GetAccountSettings(context.Background(), &GetAccountSettingsRequest{AccountId: "acc-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.AccountSettings)
+}
+
+func TestPutAccountSettings(t *testing.T) {
+	expected := AccountSettings{
+		AutoscalingEnabled:     true,
+		ShrinkEnabled:          true,
+		GrowThresholdPercent:   85,
+		ShrinkThresholdPercent: 30,
+	}
+	var gotBody map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.PutAccountSettings(context.Background(), &PutAccountSettingsRequest{AccountId: "acc-123", AccountSettings: expected})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.AccountSettings)
+	assert.Equal(t, map[string]interface{}{
+		"autoscalingEnabled":     true,
+		"shrinkEnabled":          true,
+		"growThresholdPercent":   float64(85),
+		"shrinkThresholdPercent": float64(30),
+		"notificationEmail":      "",
+	}, gotBody)
+}
+
+func TestResetAccountSettings(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.ResetAccountSettings(context.Background(), &ResetAccountSettingsRequest{AccountId: "acc-123"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, out)
+}
@@ -28,6 +28,14 @@ type GetAccountTokenResponse struct {
 	AccountToken AccountToken
 }
 
+type ListAccountTokensRequest struct {
+	AccountId string
+}
+
+type ListAccountTokensResponse struct {
+	AccountTokens []AccountToken
+}
+
 type DeleteAccountTokenRequest struct {
 	AccountId string
 	TokenId   string
@@ -92,6 +100,27 @@ func (c *Client) GetAccountToken(ctx context.Context, req *GetAccountTokenReques
 	}, nil
 }
 
+func (c *Client) ListAccountTokens(ctx context.Context, req *ListAccountTokensRequest) (*ListAccountTokensResponse, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, fmt.Sprintf("/api/v1/accounts/%s/tokens", req.AccountId), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, toError(resp)
+	}
+
+	var accountTokens []AccountToken
+	if err := json.NewDecoder(resp.Body).Decode(&accountTokens); err != nil {
+		return nil, err
+	}
+
+	return &ListAccountTokensResponse{
+		AccountTokens: accountTokens,
+	}, nil
+}
+
 func (c *Client) DeleteAccountToken(ctx context.Context, req *DeleteAccountTokenRequest) (*DeleteAccountTokenResponse, error) {
 	resp, err := c.callAPI(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/accounts/%s/tokens/%s", req.AccountId, req.TokenId), nil)
 	if err != nil {
@@ -83,6 +83,33 @@ func TestGetAccountToken(t *testing.T) {
 	assert.Equal(t, expected, out.AccountToken)
 }
 
+func TestListAccountTokens(t *testing.T) {
+	expected := []AccountToken{
+		{AccountId: "acc-123", TokenId: "tok-abc", Description: "ci", RoleIds: []string{"role-1"}},
+		{AccountId: "acc-123", TokenId: "tok-def", Description: "read-only", RoleIds: []string{"role-2"}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path != "/api/v1/accounts/acc-123/tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer ts.Close()
+
+	c := NewClient("dummy", ts.URL)
+	out, err := c.ListAccountTokens(context.Background(), &ListAccountTokensRequest{AccountId: "acc-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out.AccountTokens)
+}
+
 func TestDeleteAccountToken(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -0,0 +1,131 @@
+// Package iamarn parses and validates AWS IAM role ARNs.
+package iamarn
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Partitions lists the AWS partitions a role ARN may belong to.
+var Partitions = []string{"aws", "aws-cn", "aws-us-gov"}
+
+const (
+	maxRoleNameLength = 64
+	maxRolePathLength = 512
+)
+
+var (
+	accountIdPattern = regexp.MustCompile(`^[0-9]{12}$`)
+	roleNamePattern  = regexp.MustCompile(`^[\w+=,.@-]+$`)
+	rolePathPattern  = regexp.MustCompile(`^/([\x21-\x2E\x30-\x7E]+/)*$`)
+)
+
+// RoleArn is a parsed IAM role ARN of the form
+// arn:<partition>:iam::<account-id>:role<path><name>.
+type RoleArn struct {
+	Partition string
+	AccountId string
+	Path      string
+	Name      string
+}
+
+// ParseRoleArn parses s as an IAM role ARN and validates every component.
+func ParseRoleArn(s string) (*RoleArn, error) {
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return nil, fmt.Errorf("%q is not an ARN, expected the format arn:<partition>:iam::<account-id>:role/<role-name>", s)
+	}
+
+	partition, service, region, accountId, res := parts[1], parts[2], parts[3], parts[4], parts[5]
+
+	if !isPartition(partition) {
+		return nil, fmt.Errorf("unsupported partition %q, expected one of %s", partition, strings.Join(Partitions, ", "))
+	}
+	if service != "iam" {
+		return nil, fmt.Errorf("unsupported service %q, expected \"iam\"", service)
+	}
+	if region != "" {
+		return nil, fmt.Errorf("IAM ARNs must not have a region, got %q", region)
+	}
+	if !accountIdPattern.MatchString(accountId) {
+		return nil, fmt.Errorf("account ID %q must be exactly 12 digits", accountId)
+	}
+
+	rest, ok := strings.CutPrefix(res, "role/")
+	if !ok {
+		return nil, fmt.Errorf("resource %q must start with \"role/\"", res)
+	}
+
+	path, name := "/", rest
+	if i := strings.LastIndex(rest, "/"); i >= 0 {
+		path, name = "/"+rest[:i+1], rest[i+1:]
+	}
+
+	if len(path) > maxRolePathLength || !rolePathPattern.MatchString(path) {
+		return nil, fmt.Errorf("role path %q is invalid, it must be at most %d characters of printable ASCII separated by \"/\"", path, maxRolePathLength)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("role name must not be empty")
+	}
+	if len(name) > maxRoleNameLength {
+		return nil, fmt.Errorf("role name %q is %d characters long, the maximum is %d", name, len(name), maxRoleNameLength)
+	}
+	if !roleNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("role name %q may only contain alphanumeric characters and +=,.@_-", name)
+	}
+
+	return &RoleArn{
+		Partition: partition,
+		AccountId: accountId,
+		Path:      path,
+		Name:      name,
+	}, nil
+}
+
+// String returns the canonical ARN.
+func (a *RoleArn) String() string {
+	return fmt.Sprintf("arn:%s:iam::%s:role%s%s", a.Partition, a.AccountId, a.Path, a.Name)
+}
+
+func isPartition(p string) bool {
+	for _, partition := range Partitions {
+		if p == partition {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleArnValidator returns a schema validator that accepts IAM role ARNs in
+// any supported partition.
+func RoleArnValidator() validator.String {
+	return roleArnValidator{}
+}
+
+type roleArnValidator struct{}
+
+func (v roleArnValidator) Description(ctx context.Context) string {
+	return "value must be an IAM role ARN in the format arn:<partition>:iam::<account-id>:role/<role-name>"
+}
+
+func (v roleArnValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an IAM role ARN in the format `arn:<partition>:iam::<account-id>:role/<role-name>`"
+}
+
+func (v roleArnValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := ParseRoleArn(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IAM Role ARN",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,85 @@
+package iamarn
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRoleArn(t *testing.T) {
+	cases := []struct {
+		arn      string
+		expected RoleArn
+	}{
+		{
+			arn:      "arn:aws:iam::123456789012:role/DatafyRole",
+			expected: RoleArn{Partition: "aws", AccountId: "123456789012", Path: "/", Name: "DatafyRole"},
+		},
+		{
+			arn:      "arn:aws-cn:iam::123456789012:role/service-role/datafy",
+			expected: RoleArn{Partition: "aws-cn", AccountId: "123456789012", Path: "/service-role/", Name: "datafy"},
+		},
+		{
+			arn:      "arn:aws-us-gov:iam::123456789012:role/a/b/c/Datafy+Role=1,x.y@z_w-v",
+			expected: RoleArn{Partition: "aws-us-gov", AccountId: "123456789012", Path: "/a/b/c/", Name: "Datafy+Role=1,x.y@z_w-v"},
+		},
+		{
+			arn:      "arn:aws:iam::123456789012:role/" + strings.Repeat("r", 64),
+			expected: RoleArn{Partition: "aws", AccountId: "123456789012", Path: "/", Name: strings.Repeat("r", 64)},
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseRoleArn(tc.arn)
+		assert.NoError(t, err, tc.arn)
+		assert.Equal(t, tc.expected, *got, tc.arn)
+		assert.Equal(t, tc.arn, got.String())
+	}
+}
+
+func TestParseRoleArn_invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"DatafyRole",
+		"arn:aws:iam::123456789012:DatafyRole",
+		"arn:aws:iam::123456789012:user/DatafyRole",
+		"arn:aws:iam::1234567890123:role/DatafyRole",
+		"arn:aws:iam::12345678901:role/DatafyRole",
+		"arn:aws:iam::12345678901a:role/DatafyRole",
+		"arn:aws:iam:us-east-1:123456789012:role/DatafyRole",
+		"arn:aws:sts::123456789012:role/DatafyRole",
+		"arn:aws-iso:iam::123456789012:role/DatafyRole",
+		"arn:aws:iam::123456789012:role/",
+		"arn:aws:iam::123456789012:role/path/",
+		"arn:aws:iam::123456789012:role//DatafyRole",
+		"arn:aws:iam::123456789012:role/Datafy Role",
+		"arn:aws:iam::123456789012:role/" + strings.Repeat("r", 65),
+		"arn:aws:iam::123456789012:role/" + strings.Repeat("p", 512) + "/DatafyRole",
+	}
+
+	for _, arn := range invalid {
+		_, err := ParseRoleArn(arn)
+		assert.Error(t, err, arn)
+	}
+}
+
+func TestRoleArnValidator(t *testing.T) {
+	validate := func(v types.String) bool {
+		resp := &validator.StringResponse{}
+		RoleArnValidator().ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("arn"),
+			ConfigValue: v,
+		}, resp)
+		return !resp.Diagnostics.HasError()
+	}
+
+	assert.True(t, validate(types.StringValue("arn:aws:iam::123456789012:role/DatafyRole")))
+	assert.True(t, validate(types.StringNull()))
+	assert.True(t, validate(types.StringUnknown()))
+	assert.False(t, validate(types.StringValue("arn:aws:iam::123456789012:DatafyRole")))
+}
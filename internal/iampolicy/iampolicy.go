@@ -0,0 +1,191 @@
+// Package iampolicy renders the AWS IAM trust and permissions policies
+// Datafy requires for the role associated with an account.
+package iampolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Version identifies the revision of the policies rendered by this package.
+// It is bumped whenever the required actions change and ships with the
+// provider release that contains the change.
+const Version = "1"
+
+type FeatureSet string
+
+const (
+	// FeatureSetReadOnly grants the permissions Datafy needs to discover and
+	// monitor EC2 instances and EBS volumes.
+	FeatureSetReadOnly FeatureSet = "read_only"
+	// FeatureSetAutoscaling additionally grants the permissions Datafy needs
+	// to resize, replace and tag EBS volumes.
+	FeatureSetAutoscaling FeatureSet = "autoscaling"
+)
+
+// FeatureSets lists the supported feature sets.
+var FeatureSets = []string{string(FeatureSetReadOnly), string(FeatureSetAutoscaling)}
+
+// Document is an IAM policy document. Field order matches the order in which
+// AWS renders policies, so the JSON output is stable.
+type Document struct {
+	Version   string      `json:"Version"`
+	Statement []Statement `json:"Statement"`
+}
+
+type Statement struct {
+	Sid       string                       `json:"Sid,omitempty"`
+	Effect    string                       `json:"Effect"`
+	Principal map[string]string            `json:"Principal,omitempty"`
+	Action    []string                     `json:"Action"`
+	Resource  string                       `json:"Resource,omitempty"`
+	Condition map[string]map[string]string `json:"Condition,omitempty"`
+}
+
+// JSON returns the indented JSON representation of the document.
+func (d Document) JSON() (string, error) {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+var readOnlyStatements = []Statement{
+	{
+		Sid:    "DatafyDescribeCompute",
+		Effect: "Allow",
+		Action: []string{
+			"autoscaling:DescribeAutoScalingGroups",
+			"ec2:DescribeInstanceAttribute",
+			"ec2:DescribeInstanceStatus",
+			"ec2:DescribeInstances",
+			"ec2:DescribeRegions",
+			"ec2:DescribeSnapshots",
+			"ec2:DescribeTags",
+			"ec2:DescribeVolumeStatus",
+			"ec2:DescribeVolumes",
+			"ec2:DescribeVolumesModifications",
+			"eks:DescribeCluster",
+			"eks:DescribeNodegroup",
+			"eks:ListClusters",
+			"eks:ListNodegroups",
+		},
+		Resource: "*",
+	},
+	{
+		Sid:    "DatafyReadMetrics",
+		Effect: "Allow",
+		Action: []string{
+			"cloudwatch:GetMetricData",
+			"cloudwatch:GetMetricStatistics",
+			"cloudwatch:ListMetrics",
+		},
+		Resource: "*",
+	},
+}
+
+var autoscalingStatements = []Statement{
+	{
+		Sid:    "DatafyManageVolumes",
+		Effect: "Allow",
+		Action: []string{
+			"ec2:AttachVolume",
+			"ec2:CreateSnapshot",
+			"ec2:CreateVolume",
+			"ec2:DeleteSnapshot",
+			"ec2:DeleteVolume",
+			"ec2:DetachVolume",
+			"ec2:ModifyInstanceAttribute",
+			"ec2:ModifyVolume",
+		},
+		Resource: "*",
+	},
+	{
+		Sid:    "DatafyTagResources",
+		Effect: "Allow",
+		Action: []string{
+			"ec2:CreateTags",
+			"ec2:DeleteTags",
+		},
+		Resource: "*",
+	},
+	{
+		Sid:    "DatafyUseEncryptionKeys",
+		Effect: "Allow",
+		Action: []string{
+			"kms:CreateGrant",
+			"kms:Decrypt",
+			"kms:DescribeKey",
+			"kms:GenerateDataKeyWithoutPlaintext",
+			"kms:ReEncryptFrom",
+			"kms:ReEncryptTo",
+		},
+		Resource: "*",
+		Condition: map[string]map[string]string{
+			"Bool": {"kms:GrantIsForAWSResource": "true"},
+		},
+	},
+}
+
+// PermissionsPolicy returns the permissions policy required for the given
+// feature set.
+func PermissionsPolicy(fs FeatureSet) (Document, error) {
+	statements := append([]Statement{}, readOnlyStatements...)
+	switch fs {
+	case FeatureSetReadOnly:
+	case FeatureSetAutoscaling:
+		statements = append(statements, autoscalingStatements...)
+	default:
+		return Document{}, fmt.Errorf("unsupported feature set %q, expected one of %s", fs, strings.Join(FeatureSets, ", "))
+	}
+
+	return Document{
+		Version:   "2012-10-17",
+		Statement: statements,
+	}, nil
+}
+
+// Principals maps each AWS partition to the ARN of the Datafy principal that
+// assumes the role in that partition. The principals ship with the policies,
+// so Version must be bumped whenever one changes. A partition is only listed
+// once Datafy publishes its principal there; until then the principal has to
+// be passed to TrustPolicy explicitly.
+var Principals = map[string]string{}
+
+// Principal returns the ARN of the Datafy principal for the given AWS
+// partition.
+func Principal(partition string) (string, error) {
+	principal, ok := Principals[partition]
+	if !ok {
+		return "", fmt.Errorf("no Datafy principal is known for partition %q in policy version %s", partition, Version)
+	}
+	return principal, nil
+}
+
+// TrustPolicy returns the trust policy that allows principalArn to assume the
+// role, provided it passes externalId as sts:ExternalId.
+func TrustPolicy(principalArn, externalId string) (Document, error) {
+	if principalArn == "" {
+		return Document{}, fmt.Errorf("principal ARN must not be empty")
+	}
+	if externalId == "" {
+		return Document{}, fmt.Errorf("external ID must not be empty")
+	}
+
+	return Document{
+		Version: "2012-10-17",
+		Statement: []Statement{
+			{
+				Sid:       "DatafyAssumeRole",
+				Effect:    "Allow",
+				Principal: map[string]string{"AWS": principalArn},
+				Action:    []string{"sts:AssumeRole"},
+				Condition: map[string]map[string]string{
+					"StringEquals": {"sts:ExternalId": externalId},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,83 @@
+package iampolicy
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func assertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(golden, []byte(got+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(want), got+"\n")
+}
+
+func TestPermissionsPolicy(t *testing.T) {
+	for _, fs := range FeatureSets {
+		t.Run(fs, func(t *testing.T) {
+			doc, err := PermissionsPolicy(FeatureSet(fs))
+			assert.NoError(t, err)
+
+			got, err := doc.JSON()
+			assert.NoError(t, err)
+			assertGolden(t, "permissions_"+fs+".json", got)
+		})
+	}
+}
+
+func TestPermissionsPolicy_unsupported(t *testing.T) {
+	_, err := PermissionsPolicy("full_access")
+	assert.Error(t, err)
+}
+
+func TestTrustPolicy(t *testing.T) {
+	doc, err := TrustPolicy("arn:aws:iam::111122223333:root", "ext-456")
+	assert.NoError(t, err)
+
+	got, err := doc.JSON()
+	assert.NoError(t, err)
+	assertGolden(t, "trust.json", got)
+}
+
+func TestTrustPolicy_invalid(t *testing.T) {
+	_, err := TrustPolicy("", "ext-456")
+	assert.Error(t, err)
+
+	_, err = TrustPolicy("arn:aws:iam::111122223333:root", "")
+	assert.Error(t, err)
+}
+
+func TestPrincipal(t *testing.T) {
+	defer func(principals map[string]string) { Principals = principals }(Principals)
+	Principals = map[string]string{"aws": "arn:aws:iam::111122223333:root"}
+
+	principal, err := Principal("aws")
+	assert.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::111122223333:root", principal)
+
+	_, err = Principal("aws-cn")
+	assert.ErrorContains(t, err, `partition "aws-cn"`)
+}
+
+func TestPrincipals_partition(t *testing.T) {
+	for partition, principal := range Principals {
+		assert.Truef(t, strings.HasPrefix(principal, "arn:"+partition+":iam::"), "principal %q of partition %q", principal, partition)
+	}
+}
@@ -0,0 +1,213 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAccountAutoscalingRulesResource_basic(t *testing.T) {
+	resourceName := "datafy_account_autoscaling_rules.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountAutoscalingRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountAutoscalingRulesResourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "account_id"),
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "rules.0.rule_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "rules.1.rule_id"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.active", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountAutoscalingRulesResource_update(t *testing.T) {
+	resourceName := "datafy_account_autoscaling_rules.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountAutoscalingRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountAutoscalingRulesResourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccAccountAutoscalingRulesResourceConfigUpdated(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.active", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountAutoscalingRulesResource_outsideRule(t *testing.T) {
+	resourceName := "datafy_account_autoscaling_rules.test"
+	var accountId, outsideRuleId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountAutoscalingRulesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountAutoscalingRulesResourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+					resource.TestCheckResourceAttrWith(resourceName, "account_id", func(value string) error {
+						accountId = value
+						return nil
+					}),
+				),
+			},
+			{
+				// The "Autoscaling rules will be deleted" warning of this plan
+				// is covered by the autoscaling_rules unit tests, as warnings
+				// cannot be checked here.
+				PreConfig: func() {
+					out, err := newTestClient().CreateAccountAutoscalingRule(context.Background(), &datafy.CreateAccountAutoscalingRuleRequest{
+						AccountId: accountId,
+						Active:    false,
+						Rule:      json.RawMessage(`{"in":[{"var":"cluster_name"},["created-outside-terraform"]]}`),
+					})
+					if err != nil {
+						t.Fatalf("creating autoscaling rule outside of terraform: %s", err)
+					}
+					outsideRuleId = out.AutoscalingRule.RuleId
+				},
+				Config: testAccAccountAutoscalingRulesResourceConfig(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectNonEmptyPlan(),
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.#", "2"),
+					testAccCheckAccountAutoscalingRuleDeleted(resourceName, &outsideRuleId),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckAccountAutoscalingRuleDeleted checks that the account of
+// resourceName no longer has the rule with the given ID.
+func testAccCheckAccountAutoscalingRuleDeleted(resourceName string, ruleId *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		out, err := newTestClient().ListAccountAutoscalingRules(context.Background(), &datafy.ListAccountAutoscalingRulesRequest{
+			AccountId: rs.Primary.Attributes["account_id"],
+		})
+		if err != nil {
+			return err
+		}
+		for _, rule := range out.AutoscalingRules {
+			if rule.RuleId == *ruleId {
+				return fmt.Errorf("autoscaling rule %s still exists after apply", *ruleId)
+			}
+		}
+		if len(out.AutoscalingRules) != 2 {
+			return fmt.Errorf("expected 2 autoscaling rules after apply, got %d", len(out.AutoscalingRules))
+		}
+		return nil
+	}
+}
+
+func testAccCheckAccountAutoscalingRulesDestroy(s *terraform.State) error {
+	client := newTestClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "datafy_account_autoscaling_rules" {
+			continue
+		}
+
+		out, err := client.ListAccountAutoscalingRules(context.Background(), &datafy.ListAccountAutoscalingRulesRequest{
+			AccountId: rs.Primary.Attributes["account_id"],
+		})
+		if err == nil && len(out.AutoscalingRules) > 0 {
+			return fmt.Errorf("account %s still has %d autoscaling rules after destroy", rs.Primary.Attributes["account_id"], len(out.AutoscalingRules))
+		}
+	}
+
+	return nil
+}
+
+func testAccAccountAutoscalingRulesResourceConfig() string {
+	return `
+resource "datafy_account" "test" {
+  name                = "regression-test-rules"
+  deletion_protection = false
+}
+
+resource "datafy_account_autoscaling_rules" "test" {
+  account_id = datafy_account.test.id
+
+  rules = [
+    {
+      active = true
+      rule = jsonencode({
+        "in" = [
+          { "var" = "cluster_name" },
+          ["regression-test-cluster"]
+        ]
+      })
+    },
+    {
+      active = false
+      rule = jsonencode({
+        "in" = [
+          { "var" = "node_group_name" },
+          ["regression-test-nodegroup"]
+        ]
+      })
+    },
+  ]
+}
+`
+}
+
+func testAccAccountAutoscalingRulesResourceConfigUpdated() string {
+	return `
+resource "datafy_account" "test" {
+  name                = "regression-test-rules"
+  deletion_protection = false
+}
+
+resource "datafy_account_autoscaling_rules" "test" {
+  account_id = datafy_account.test.id
+
+  rules = [
+    {
+      active = false
+      rule = jsonencode({
+        "in" = [
+          { "var" = "cluster_name" },
+          ["regression-test-cluster"]
+        ]
+      })
+    },
+  ]
+}
+`
+}
@@ -1,6 +1,7 @@
 package provider_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -18,16 +19,48 @@ func TestAccAccountDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
 					resource.TestCheckResourceAttrSet(resourceName, "name"),
 					resource.TestCheckResourceAttrSet(resourceName, "parent_account_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "external_id"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccAccountDataSource_labelFilter(t *testing.T) {
+	resourceName := "data.datafy_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountDataSourceConfigLabelFilter(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "datafy_account.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "description", "Looked up by labels"),
+					resource.TestCheckResourceAttr(resourceName, "labels.lookup", "regression-test-account-ds"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountDataSource_missingLookup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "datafy_account" "test" {}`,
+				ExpectError: regexp.MustCompile("At least one of id or label_filter must be set"),
+			},
+		},
+	})
+}
+
 func testAccAccountDataSourceConfig() string {
 	return `
 resource "datafy_account" "test" {
-  name = "regression-test-account-ds"
+  name                = "regression-test-account-ds"
+  deletion_protection = false
 }
 
 data "datafy_account" "test" {
@@ -35,3 +68,25 @@ data "datafy_account" "test" {
 }
 `
 }
+
+func testAccAccountDataSourceConfigLabelFilter() string {
+	return `
+resource "datafy_account" "test" {
+  name                = "regression-test-account-ds"
+  description         = "Looked up by labels"
+  deletion_protection = false
+
+  labels = {
+    lookup = "regression-test-account-ds"
+  }
+}
+
+data "datafy_account" "test" {
+  label_filter = {
+    lookup = "regression-test-account-ds"
+  }
+
+  depends_on = [datafy_account.test]
+}
+`
+}
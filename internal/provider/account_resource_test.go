@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -24,6 +27,7 @@ func TestAccAccountResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", "regression-test-account"),
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
 					resource.TestCheckResourceAttrSet(resourceName, "parent_account_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "external_id"),
 				),
 			},
 		},
@@ -55,6 +59,250 @@ func TestAccAccountResource_update(t *testing.T) {
 	})
 }
 
+func TestAccAccountResource_labels(t *testing.T) {
+	resourceName := "datafy_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigLabels("Checkout team", "checkout", "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Checkout team"),
+					resource.TestCheckResourceAttr(resourceName, "labels.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "labels.team", "checkout"),
+					resource.TestCheckResourceAttr(resourceName, "labels.env", "prod"),
+					resource.TestCheckResourceAttr(resourceName, "labels_all.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "labels_all.cost_center", "1234"),
+				),
+			},
+			{
+				// Labels on the account override default labels.
+				Config: testAccAccountResourceConfigLabels("Checkout team, staging", "checkout", "staging"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", "Checkout team, staging"),
+					resource.TestCheckResourceAttr(resourceName, "labels.env", "staging"),
+					resource.TestCheckResourceAttr(resourceName, "labels_all.env", "staging"),
+					resource.TestCheckResourceAttr(resourceName, "labels_all.cost_center", "1234"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection", "force_destroy", "timeouts"},
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_timeouts(t *testing.T) {
+	resourceName := "datafy_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigTimeouts("regression-test-account", "20m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "timeouts.create", "20m"),
+					resource.TestCheckResourceAttr(resourceName, "timeouts.delete", "20m"),
+					testAccCheckAccountExists(resourceName),
+				),
+			},
+			{
+				// Replacing the account deletes it and immediately creates
+				// one with the same name.
+				Config: testAccAccountResourceConfigTimeouts("regression-test-account", "20m"),
+				Taint:  []string{resourceName},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "regression-test-account"),
+					testAccCheckAccountExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_parentAccount(t *testing.T) {
+	resourceName := "datafy_account.child"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigParent("regression-test-child"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "regression-test-child"),
+					resource.TestCheckResourceAttrPair(resourceName, "parent_account_id", "datafy_account.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_parentAccountUnreachable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "datafy_account" "test" {
+  name                = "regression-test-account"
+  parent_account_id   = "00000000-0000-0000-0000-000000000000"
+  deletion_protection = false
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid Parent Account"),
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_forceDestroy(t *testing.T) {
+	resourceName := "datafy_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigForceDestroy("regression-test-account-force", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "false"),
+					// A token created outside of Terraform keeps the
+					// account from being deleted.
+					testAccCreateAccountToken(resourceName),
+				),
+			},
+			{
+				Config:      testAccAccountResourceConfigForceDestroy("regression-test-account-force", false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Account Not Empty`),
+			},
+			{
+				// The final destroy revokes the token along with the
+				// account.
+				Config: testAccAccountResourceConfigForceDestroy("regression-test-account-force", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+					testAccCheckAccountExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_deletionProtection(t *testing.T) {
+	resourceName := "datafy_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountResourceConfigDefaultProtection("regression-test-account-protected"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccAccountResourceConfigDefaultProtection("regression-test-account-protected"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Account Deletion Protected`),
+			},
+			{
+				// The account survived the destroy attempt; unprotect it so
+				// the final destroy succeeds.
+				Config: testAccAccountResourceConfig("regression-test-account-protected"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+					testAccCheckAccountExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountResource_deletionProtectionUpgrade(t *testing.T) {
+	resourceName := "datafy_account.test"
+	var accountId string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				// An imported account has no deletion_protection in state,
+				// like one created before the attribute existed.
+				PreConfig: func() {
+					car, err := newTestClient().CreateAccount(context.Background(), &datafy.CreateAccountRequest{
+						AccountName: "regression-test-account-upgrade",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					accountId = car.Account.AccountId
+				},
+				Config:             testAccAccountResourceConfigDefaultProtection("regression-test-account-upgrade"),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return accountId, nil
+				},
+			},
+			{
+				// Upgrading must not enable the default of new accounts.
+				Config: testAccAccountResourceConfigDefaultProtection("regression-test-account-upgrade"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAccountExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		_, err := newTestClient().GetAccount(context.Background(), &datafy.GetAccountRequest{
+			AccountId: rs.Primary.Attributes["id"],
+		})
+		return err
+	}
+}
+
+func testAccCreateAccountToken(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		_, err := newTestClient().CreateAccountToken(context.Background(), &datafy.CreateAccountTokenRequest{
+			AccountId:   rs.Primary.Attributes["id"],
+			Description: "created outside of terraform",
+			Ttl:         time.Hour,
+			RoleIds:     []string{},
+		})
+		return err
+	}
+}
+
 func testAccCheckAccountDestroy(s *terraform.State) error {
 	client := newTestClient()
 
@@ -77,7 +325,8 @@ func testAccCheckAccountDestroy(s *terraform.State) error {
 func testAccAccountResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "datafy_account" "test" {
-  name = %q
+  name                = %q
+  deletion_protection = false
 }
 `, name)
 }
@@ -86,7 +335,79 @@ func newTestClient() *datafy.Client {
 	token := os.Getenv("DATAFY_TOKEN")
 	endpoint := os.Getenv("DATAFY_ENDPOINT")
 	if endpoint == "" {
-		endpoint = "https://api.datafy.io"
+		endpoint = datafy.DefaultEndpoint
+	}
+	if normalized, err := datafy.ParseEndpoint(endpoint, true); err == nil {
+		endpoint = normalized
 	}
 	return datafy.NewClient(token, endpoint)
 }
+
+func testAccAccountResourceConfigDefaultProtection(name string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name = %q
+}
+`, name)
+}
+
+func testAccAccountResourceConfigParent(name string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "parent" {
+  name                = "regression-test-parent"
+  deletion_protection = false
+}
+
+resource "datafy_account" "child" {
+  name                = %q
+  parent_account_id   = datafy_account.parent.id
+  deletion_protection = false
+}
+`, name)
+}
+
+func testAccAccountResourceConfigTimeouts(name, timeout string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = %q
+  deletion_protection = false
+
+  timeouts {
+    create = %[2]q
+    delete = %[2]q
+  }
+}
+`, name, timeout)
+}
+
+func testAccAccountResourceConfigLabels(description, team, env string) string {
+	return fmt.Sprintf(`
+provider "datafy" {
+  default_labels = {
+    cost_center = "1234"
+    env         = "prod"
+  }
+}
+
+resource "datafy_account" "test" {
+  name                = "regression-test-account"
+  description         = %q
+  deletion_protection = false
+
+  labels = {
+    team = %q
+    env  = %q
+  }
+}
+`, description, team, env)
+}
+
+func testAccAccountResourceConfigForceDestroy(name string, forceDestroy bool) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = %q
+  deletion_protection = false
+  force_destroy       = %t
+}
+`, name, forceDestroy)
+}
@@ -0,0 +1,124 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAccountSettingsResource_basic(t *testing.T) {
+	resourceName := "datafy_account_settings.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountSettingsResourceConfig(80, 40, "storage@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "account_id", "datafy_account.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "autoscaling_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "grow_threshold_percent", "80"),
+					resource.TestCheckResourceAttr(resourceName, "shrink_threshold_percent", "40"),
+					resource.TestCheckResourceAttr(resourceName, "notification_email", "storage@example.com"),
+					resource.TestCheckResourceAttrSet(resourceName, "shrink_enabled"),
+				),
+			},
+			{
+				Config: testAccAccountSettingsResourceConfig(90, 30, "platform@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "grow_threshold_percent", "90"),
+					resource.TestCheckResourceAttr(resourceName, "shrink_threshold_percent", "30"),
+					resource.TestCheckResourceAttr(resourceName, "notification_email", "platform@example.com"),
+				),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccAccountSettingsImportStateIdFunc(resourceName),
+				ImportStateVerifyIdentifierAttribute: "account_id",
+			},
+			{
+				// Destroying the settings resets them to the service
+				// defaults.
+				Config: testAccAccountResourceConfig("regression-test-account-settings"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAccountSettingsReset("datafy_account.test", "platform@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccountSettingsResource_invalidThresholds(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAccountSettingsResourceConfig(40, 80, "storage@example.com"),
+				ExpectError: regexp.MustCompile("must be lower than grow_threshold_percent"),
+			},
+			{
+				Config:      testAccAccountSettingsResourceConfig(100, 40, "storage@example.com"),
+				ExpectError: regexp.MustCompile("must be between 1 and 99"),
+			},
+			{
+				Config:      testAccAccountSettingsResourceConfig(80, 40, "not-an-email"),
+				ExpectError: regexp.MustCompile("Invalid Email Address"),
+			},
+		},
+	})
+}
+
+func testAccAccountSettingsImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		return rs.Primary.Attributes["account_id"], nil
+	}
+}
+
+func testAccCheckAccountSettingsReset(accountResourceName, email string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[accountResourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", accountResourceName)
+		}
+
+		gasr, err := newTestClient().GetAccountSettings(context.Background(), &datafy.GetAccountSettingsRequest{
+			AccountId: rs.Primary.Attributes["id"],
+		})
+		if err != nil {
+			return err
+		}
+		if gasr.AccountSettings.NotificationEmail == email {
+			return fmt.Errorf("account settings were not reset, notification email is still %s", email)
+		}
+		return nil
+	}
+}
+
+func testAccAccountSettingsResourceConfig(grow, shrink int, email string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = "regression-test-account-settings"
+  deletion_protection = false
+}
+
+resource "datafy_account_settings" "test" {
+  account_id               = datafy_account.test.id
+  autoscaling_enabled      = true
+  grow_threshold_percent   = %d
+  shrink_threshold_percent = %d
+  notification_email       = %q
+}
+`, grow, shrink, email)
+}
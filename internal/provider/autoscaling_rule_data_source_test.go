@@ -26,7 +26,8 @@ func TestAccAutoscalingRuleDataSource_basic(t *testing.T) {
 func testAccAutoscalingRuleDataSourceConfig() string {
 	return `
 resource "datafy_account" "test" {
-  name = "regression-test-rule-ds"
+  name                = "regression-test-rule-ds"
+  deletion_protection = false
 }
 
 resource "datafy_autoscaling_rule" "test" {
@@ -0,0 +1,45 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAutoscalingRulePreviewDataSource_basic(t *testing.T) {
+	resourceName := "data.datafy_autoscaling_rule_preview.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingRulePreviewDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "volume_count"),
+					resource.TestCheckResourceAttrSet(resourceName, "total_size_gib"),
+					resource.TestCheckResourceAttrSet(resourceName, "volume_ids.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "instance_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAutoscalingRulePreviewDataSourceConfig() string {
+	return `
+resource "datafy_account" "test" {
+  name                = "regression-test-rule-preview"
+  deletion_protection = false
+}
+
+data "datafy_autoscaling_rule_preview" "test" {
+  account_id = datafy_account.test.id
+  rule       = jsonencode({
+    "in" = [
+      { "var" = "cluster_name" },
+      ["regression-test-cluster"]
+    ]
+  })
+}
+`
+}
@@ -3,6 +3,7 @@ package provider_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
@@ -53,6 +54,128 @@ func TestAccAutoscalingRuleResource_update(t *testing.T) {
 	})
 }
 
+func TestAccAutoscalingRuleResource_metadata(t *testing.T) {
+	resourceName := "datafy_autoscaling_rule.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAutoscalingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingRuleResourceConfigMetadata("regression-test-rule", 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "regression-test-rule"),
+					resource.TestCheckResourceAttr(resourceName, "description", "regression test rule"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "10"),
+				),
+			},
+			{
+				Config: testAccAutoscalingRuleResourceConfigMetadata("regression-test-rule-updated", 20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "regression-test-rule-updated"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "20"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAutoscalingRuleResource_withoutPriority(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAutoscalingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Both rules get a priority assigned by Datafy instead of
+				// colliding on 0.
+				Config: testAccAutoscalingRuleResourceConfigWithoutPriority(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("datafy_autoscaling_rule.first", "priority"),
+					resource.TestCheckResourceAttrSet("datafy_autoscaling_rule.second", "priority"),
+					testAccCheckResourceAttrsDiffer("datafy_autoscaling_rule.first", "datafy_autoscaling_rule.second", "priority"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckResourceAttrsDiffer checks that the attribute key has different
+// values in the two resources.
+func testAccCheckResourceAttrsDiffer(first, second, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var values []string
+		for _, name := range []string{first, second} {
+			rs, ok := s.RootModule().Resources[name]
+			if !ok {
+				return fmt.Errorf("resource %s not found in state", name)
+			}
+			values = append(values, rs.Primary.Attributes[key])
+		}
+		if values[0] == values[1] {
+			return fmt.Errorf("%s and %s have the same %s: %s", first, second, key, values[0])
+		}
+		return nil
+	}
+}
+
+func TestAccAutoscalingRuleResource_schedule(t *testing.T) {
+	resourceName := "datafy_autoscaling_rule.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAutoscalingRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingRuleResourceConfigSchedule(`
+    cron     = "0 2 * * SAT"
+    duration = "4h"
+    timezone = "Europe/Berlin"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule.cron", "0 2 * * SAT"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.duration", "4h"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.timezone", "Europe/Berlin"),
+					resource.TestCheckResourceAttrSet(resourceName, "effective_active"),
+				),
+			},
+			{
+				Config: testAccAutoscalingRuleResourceConfigSchedule(`
+    active_from  = "2020-01-01T00:00:00Z"
+    active_until = "2099-01-01T00:00:00Z"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "schedule.cron"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.active_from", "2020-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(resourceName, "effective_active", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAutoscalingRuleResource_invalidSchedule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutoscalingRuleResourceConfigSchedule(`
+    cron     = "0 25 * * *"
+    duration = "4h"
+`),
+				ExpectError: regexp.MustCompile(`Invalid Cron Expression`),
+			},
+			{
+				Config: testAccAutoscalingRuleResourceConfigSchedule(`
+    cron         = "0 2 * * SAT"
+    duration     = "4h"
+    active_until = "2099-01-01T00:00:00Z"
+`),
+				ExpectError: regexp.MustCompile(`Invalid Schedule`),
+			},
+		},
+	})
+}
+
 func testAccCheckAutoscalingRuleDestroy(s *terraform.State) error {
 	client := newTestClient()
 
@@ -80,7 +203,8 @@ func testAccAutoscalingRuleResourceConfig(active bool) string {
 	}
 	return `
 resource "datafy_account" "test" {
-  name = "regression-test-rule"
+  name                = "regression-test-rule"
+  deletion_protection = false
 }
 
 resource "datafy_autoscaling_rule" "test" {
@@ -99,7 +223,8 @@ resource "datafy_autoscaling_rule" "test" {
 func testAccAutoscalingRuleResourceConfigUpdated() string {
 	return `
 resource "datafy_account" "test" {
-  name = "regression-test-rule"
+  name                = "regression-test-rule"
+  deletion_protection = false
 }
 
 resource "datafy_autoscaling_rule" "test" {
@@ -124,3 +249,79 @@ resource "datafy_autoscaling_rule" "test" {
 }
 `
 }
+
+func testAccAutoscalingRuleResourceConfigWithoutPriority() string {
+	return `
+resource "datafy_account" "test" {
+  name                = "regression-test-rule"
+  deletion_protection = false
+}
+
+resource "datafy_autoscaling_rule" "first" {
+  account_id = datafy_account.test.id
+  active     = true
+  rule       = jsonencode({
+    "in" = [
+      { "var" = "cluster_name" },
+      ["regression-test-cluster"]
+    ]
+  })
+}
+
+resource "datafy_autoscaling_rule" "second" {
+  account_id = datafy_account.test.id
+  active     = true
+  rule       = jsonencode({
+    "in" = [
+      { "var" = "node_group_name" },
+      ["regression-test-nodegroup"]
+    ]
+  })
+}
+`
+}
+
+func testAccAutoscalingRuleResourceConfigMetadata(name string, priority int) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = "regression-test-rule"
+  deletion_protection = false
+}
+
+resource "datafy_autoscaling_rule" "test" {
+  account_id  = datafy_account.test.id
+  name        = %q
+  description = "regression test rule"
+  priority    = %d
+  active      = true
+  rule        = jsonencode({
+    "in" = [
+      { "var" = "cluster_name" },
+      ["regression-test-cluster"]
+    ]
+  })
+}
+`, name, priority)
+}
+
+func testAccAutoscalingRuleResourceConfigSchedule(schedule string) string {
+	return `
+resource "datafy_account" "test" {
+  name                = "regression-test-rule"
+  deletion_protection = false
+}
+
+resource "datafy_autoscaling_rule" "test" {
+  account_id = datafy_account.test.id
+  active     = true
+  rule       = jsonencode({
+    "in" = [
+      { "var" = "cluster_name" },
+      ["regression-test-cluster"]
+    ]
+  })
+
+  schedule = {` + schedule + `  }
+}
+`
+}
@@ -0,0 +1,119 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAwsAccountAssociationResource_basic(t *testing.T) {
+	resourceName := "datafy_aws_account_association.first"
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAwsAccountAssociationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsAccountAssociationResourceConfig("regression-test-role"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "aws_account_id", "123456789012"),
+					resource.TestCheckResourceAttr(resourceName, "role_arn", "arn:aws:iam::123456789012:role/regression-test-role"),
+					resource.TestCheckResourceAttrPair(resourceName, "external_id", "datafy_account.test", "external_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "validation_status"),
+					resource.TestCheckResourceAttr("datafy_aws_account_association.second", "aws_account_id", "210987654321"),
+				),
+			},
+			{
+				Config: testAccAwsAccountAssociationResourceConfig("regression-test-role-updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "role_arn", "arn:aws:iam::123456789012:role/regression-test-role-updated"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[resourceName]
+					return rs.Primary.Attributes["account_id"] + ":" + rs.Primary.Attributes["aws_account_id"], nil
+				},
+				ImportStateVerifyIdentifierAttribute: "aws_account_id",
+				ImportStateVerifyIgnore:              []string{"skip_validation"},
+			},
+		},
+	})
+}
+
+func TestAccAwsAccountAssociationResource_roleInOtherAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "datafy_aws_account_association" "test" {
+  account_id      = "79c406c5-7b64-43f2-ba76-9b01e74e3d90"
+  aws_account_id  = "123456789012"
+  role_arn        = "arn:aws:iam::210987654321:role/regression-test-role"
+  skip_validation = true
+}
+`,
+				ExpectError: regexp.MustCompile(`IAM Role In Different AWS Account`),
+			},
+		},
+	})
+}
+
+func testAccCheckAwsAccountAssociationDestroy(s *terraform.State) error {
+	client := newTestClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "datafy_aws_account_association" {
+			continue
+		}
+
+		laar, err := client.ListAwsAccountAssociations(context.Background(), &datafy.ListAwsAccountAssociationsRequest{
+			AccountId: rs.Primary.Attributes["account_id"],
+		})
+		if err != nil {
+			// The account itself is destroyed along with its associations.
+			continue
+		}
+		for _, association := range laar.AwsAccountAssociations {
+			if association.AwsAccountId == rs.Primary.Attributes["aws_account_id"] {
+				return fmt.Errorf("aws account association %s:%s still exists after destroy", rs.Primary.Attributes["account_id"], association.AwsAccountId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccAwsAccountAssociationResourceConfig(roleName string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = "regression-test-aws-account-association"
+  deletion_protection = false
+}
+
+resource "datafy_aws_account_association" "first" {
+  account_id      = datafy_account.test.id
+  aws_account_id  = "123456789012"
+  role_arn        = "arn:aws:iam::123456789012:role/%[1]s"
+  skip_validation = true
+}
+
+resource "datafy_aws_account_association" "second" {
+  account_id      = datafy_account.test.id
+  aws_account_id  = "210987654321"
+  role_arn        = "arn:aws:iam::210987654321:role/%[1]s"
+  skip_validation = true
+}
+`, roleName)
+}
@@ -0,0 +1,35 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCallerIdentityDataSource_basic(t *testing.T) {
+	resourceName := "data.datafy_caller_identity.current"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCallerIdentityDataSourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "account_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "token_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "role_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCallerIdentityDataSourceConfig() string {
+	return `
+provider "datafy" {
+  verify_credentials = true
+}
+
+data "datafy_caller_identity" "current" {}
+`
+}
@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/credentials"
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// credentialSource describes where the provider's credentials come from, so
+// that diagnostics about them point at the right place.
+type credentialSource struct {
+	// attribute is the provider attribute that sets the credentials, or that
+	// would be set to override them.
+	attribute string
+	// description names the source, e.g. "the DATAFY_TOKEN environment
+	// variable".
+	description string
+}
+
+// resolveCredentials returns the token source and endpoint the provider
+// uses, and where the credentials come from. OAuth2 client credentials, if set, are used instead of a token.
+// Otherwise the token is taken from the first of these sources that sets
+// one:
+//
+//  1. the token, token_file or credential_process provider attribute
+//  2. the profile selected by the profile provider attribute
+//  3. the DATAFY_TOKEN or DATAFY_TOKEN_FILE environment variable
+//  4. the profile selected by the DATAFY_PROFILE environment variable, or
+//     the default profile if the credentials file has one
+//
+// The endpoint is taken from the endpoint provider attribute, the profile
+// that supplied the token, or the DATAFY_ENDPOINT environment variable, in
+// that order. Without an endpoint, the endpoint of the configured region or
+// https://api.datafy.io is used.
+func resolveCredentials(ctx context.Context, config DatafyProviderConfig, httpClient *http.Client) (datafy.TokenSource, string, credentialSource, diag.Diagnostics) {
+	var set []string
+	if !config.Token.IsNull() {
+		set = append(set, "token")
+	}
+	if !config.TokenFile.IsNull() {
+		set = append(set, "token_file")
+	}
+	if !config.CredentialProcess.IsNull() {
+		set = append(set, "credential_process")
+	}
+	if len(set) > 1 {
+		var diags diag.Diagnostics
+		diags.AddAttributeError(
+			path.Root(set[1]),
+			"Conflicting Token Configuration",
+			fmt.Sprintf("Only one of token, token_file and credential_process can be set, got %s.", strings.Join(set, " and ")),
+		)
+		return nil, "", credentialSource{}, diags
+	}
+
+	clientCredentials, diags := resolveClientCredentials(config, set, httpClient)
+	if diags.HasError() {
+		return nil, "", credentialSource{}, diags
+	}
+
+	configToken := config.Token.ValueString()
+	if !config.TokenFile.IsNull() {
+		token, err := credentials.ReadTokenFile(config.TokenFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("token_file"),
+				"Invalid Token File",
+				fmt.Sprintf("Cannot read the token from token_file: %s", err.Error()),
+			)
+		}
+		configToken = token
+	}
+
+	envToken, envTokenSource := os.Getenv("DATAFY_TOKEN"), credentialSource{"token", "the DATAFY_TOKEN environment variable"}
+	if tokenFile := os.Getenv("DATAFY_TOKEN_FILE"); envToken == "" && tokenFile != "" {
+		token, err := credentials.ReadTokenFile(tokenFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("token_file"),
+				"Invalid Token File",
+				fmt.Sprintf("Cannot read the token from the DATAFY_TOKEN_FILE environment variable: %s", err.Error()),
+			)
+		}
+		envToken = token
+		envTokenSource = credentialSource{"token_file", "the token file set by the DATAFY_TOKEN_FILE environment variable"}
+	}
+
+	// A profile selected in the provider configuration takes precedence over
+	// the environment, one selected by DATAFY_PROFILE or the default profile
+	// does not.
+	var configProfile, envProfile credentials.Profile
+	var profileName string
+	if !config.Profile.IsNull() {
+		profileName = config.Profile.ValueString()
+		configProfile = loadProfile(profileName, "the profile attribute", &diags)
+	} else if name := os.Getenv("DATAFY_PROFILE"); name != "" {
+		profileName = name
+		envProfile = loadProfile(name, "the DATAFY_PROFILE environment variable", &diags)
+	} else {
+		profileName = credentials.DefaultProfile
+		envProfile = loadProfile(credentials.DefaultProfile, "", &diags)
+	}
+
+	if diags.HasError() {
+		return nil, "", credentialSource{}, diags
+	}
+
+	// A profile's endpoint is only used together with the profile's token, so
+	// that a token is never sent to an endpoint meant for another one.
+	var token, profileEndpoint string
+	source := credentialSource{"token", "the token attribute"}
+	profileSource := credentialSource{"profile", fmt.Sprintf("the profile %q in the shared credentials file", profileName)}
+	if clientCredentials == nil && config.CredentialProcess.IsNull() {
+		switch {
+		case configToken != "":
+			token = configToken
+			if !config.TokenFile.IsNull() {
+				source = credentialSource{"token_file", "the token file set by the token_file attribute"}
+			}
+		case configProfile.Token != "":
+			token, profileEndpoint, source = configProfile.Token, configProfile.Endpoint, profileSource
+		case envToken != "":
+			token, source = envToken, envTokenSource
+		case envProfile.Token != "":
+			token, profileEndpoint, source = envProfile.Token, envProfile.Endpoint, profileSource
+		}
+	}
+
+	endpoint, endpointDiags := resolveEndpoint(config, firstNonEmpty(config.Endpoint.ValueString(), profileEndpoint, os.Getenv("DATAFY_ENDPOINT")))
+	diags.Append(endpointDiags...)
+	if diags.HasError() {
+		return nil, "", credentialSource{}, diags
+	}
+
+	if clientCredentials != nil {
+		// Fetch an access token now, so that invalid client credentials are
+		// reported against the provider configuration rather than the first
+		// API call.
+		if _, err := clientCredentials.Token(ctx); err != nil {
+			diags.AddAttributeError(
+				path.Root("client_id"),
+				"Invalid Client Credentials",
+				fmt.Sprintf("Cannot obtain an access token with the OAuth2 client credentials: %s", err.Error()),
+			)
+		}
+		return clientCredentials, endpoint, credentialSource{"client_id", "the OAuth2 client credentials"}, diags
+	}
+
+	if !config.CredentialProcess.IsNull() {
+		// Run the process now, so that a failing helper is reported against
+		// the provider configuration rather than the first API call.
+		process := datafy.NewCredentialProcess(config.CredentialProcess.ValueString())
+		if _, err := process.Token(ctx); err != nil {
+			diags.AddAttributeError(
+				path.Root("credential_process"),
+				"Credential Process Failed",
+				fmt.Sprintf("Cannot obtain a token from credential_process: %s", err.Error()),
+			)
+		}
+		return process, endpoint, credentialSource{"credential_process", "the token returned by credential_process"}, diags
+	}
+
+	if token == "" {
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Missing Token",
+			"Cannot create Datafy Provider as there is a missing or empty value. Set the token, token_file or credential_process attribute, OAuth2 client credentials, "+
+				"the DATAFY_TOKEN or DATAFY_TOKEN_FILE environment variable, or a token in the shared credentials file, ~/.datafy/credentials.",
+		)
+	}
+
+	return datafy.StaticToken(token), endpoint, source, diags
+}
+
+// resolveClientCredentials returns a token source for the OAuth2 client
+// credentials set by the client_id, client_secret and token_url attributes or
+// the DATAFY_CLIENT_ID, DATAFY_CLIENT_SECRET and DATAFY_TOKEN_URL
+// environment variables, or nil if they are not set. tokenAttributes are the
+// token attributes that are set, which conflict with client credentials.
+func resolveClientCredentials(config DatafyProviderConfig, tokenAttributes []string, httpClient *http.Client) (*datafy.ClientCredentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var clientSet []string
+	for _, v := range []struct {
+		attribute, env string
+		value          types.String
+	}{
+		{"client_id", "DATAFY_CLIENT_ID", config.ClientId},
+		{"client_secret", "DATAFY_CLIENT_SECRET", config.ClientSecret},
+	} {
+		if !v.value.IsNull() {
+			clientSet = append(clientSet, v.attribute)
+		} else if os.Getenv(v.env) != "" {
+			clientSet = append(clientSet, v.env)
+		}
+	}
+	if len(clientSet) == 0 {
+		return nil, diags
+	}
+
+	tokenSet := tokenAttributes
+	for _, env := range []string{"DATAFY_TOKEN", "DATAFY_TOKEN_FILE"} {
+		if os.Getenv(env) != "" {
+			tokenSet = append(tokenSet, env)
+		}
+	}
+	if len(tokenSet) > 0 {
+		diags.AddAttributeError(
+			path.Root("client_id"),
+			"Conflicting Token Configuration",
+			fmt.Sprintf("A token and OAuth2 client credentials cannot both be set. The token is set by %s, and the client credentials by %s. "+
+				"Remove either the token or the client credentials.", strings.Join(tokenSet, " and "), strings.Join(clientSet, " and ")),
+		)
+		return nil, diags
+	}
+
+	clientId := firstNonEmpty(config.ClientId.ValueString(), os.Getenv("DATAFY_CLIENT_ID"))
+	clientSecret := firstNonEmpty(config.ClientSecret.ValueString(), os.Getenv("DATAFY_CLIENT_SECRET"))
+	tokenURL := firstNonEmpty(config.TokenURL.ValueString(), os.Getenv("DATAFY_TOKEN_URL"))
+
+	for _, missing := range []struct {
+		attribute, env, summary, value string
+	}{
+		{"client_id", "DATAFY_CLIENT_ID", "Missing Client ID", clientId},
+		{"client_secret", "DATAFY_CLIENT_SECRET", "Missing Client Secret", clientSecret},
+		{"token_url", "DATAFY_TOKEN_URL", "Missing Token URL", tokenURL},
+	} {
+		if missing.value == "" {
+			diags.AddAttributeError(
+				path.Root(missing.attribute),
+				missing.summary,
+				fmt.Sprintf("OAuth2 client credentials require %s, which can also be configured using the %s environment variable.", missing.attribute, missing.env),
+			)
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// The client secret is sent to the token URL, so it is held to the same
+	// rules as the API endpoint.
+	normalized, err := datafy.ParseEndpoint(tokenURL, config.AllowInsecureEndpoint.ValueBool())
+	if errors.Is(err, datafy.ErrInsecureEndpoint) {
+		diags.AddAttributeError(
+			path.Root("token_url"),
+			"Insecure Token URL",
+			fmt.Sprintf("The OAuth2 token URL %q does not use HTTPS, so the client secret would be sent unencrypted. "+
+				"Use an https:// URL, or set allow_insecure_endpoint = true, e.g. for a local test server.", tokenURL),
+		)
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("token_url"),
+			"Invalid Token URL",
+			fmt.Sprintf("Cannot use %q as the OAuth2 token URL: %s", tokenURL, err.Error()),
+		)
+		return nil, diags
+	}
+
+	return datafy.NewClientCredentials(clientId, clientSecret, normalized, httpClient), diags
+}
+
+// loadProfile reads the named profile from the shared credentials file.
+// selectedBy describes where the profile name came from, or is empty for the
+// default profile. A missing file or profile is only an error if the profile
+// was selected explicitly.
+func loadProfile(name, selectedBy string, diags *diag.Diagnostics) credentials.Profile {
+	filePath, err := credentials.DefaultFilePath()
+	if err != nil {
+		if selectedBy != "" {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Invalid Profile",
+				fmt.Sprintf("Cannot locate the shared credentials file for the profile %q selected by %s: %s", name, selectedBy, err.Error()),
+			)
+		}
+		return credentials.Profile{}
+	}
+
+	profile, err := credentials.LoadProfile(filePath, name)
+	if err != nil {
+		notFound := errors.Is(err, fs.ErrNotExist) || errors.Is(err, credentials.ErrProfileNotFound)
+		if selectedBy != "" || !notFound {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Invalid Profile",
+				fmt.Sprintf("Cannot read the profile %q from the shared credentials file: %s", name, err.Error()),
+			)
+		}
+		return credentials.Profile{}
+	}
+
+	return *profile
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// resolveEndpoint returns the normalized Datafy API endpoint. endpoint is
+// the endpoint set by the provider configuration, the environment or a
+// profile, if any. Otherwise the endpoint of the region set by the region
+// attribute or the DATAFY_REGION environment variable is used, or the
+// default endpoint.
+func resolveEndpoint(config DatafyProviderConfig, endpoint string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attribute := path.Root("endpoint")
+	if endpoint == "" {
+		attribute = path.Root("region")
+		endpoint = datafy.DefaultEndpoint
+
+		if region := firstNonEmpty(config.Region.ValueString(), os.Getenv("DATAFY_REGION")); region != "" {
+			regionEndpoint, ok := datafy.RegionEndpoints[region]
+			if !ok {
+				diags.AddAttributeError(
+					attribute,
+					"Invalid Region",
+					fmt.Sprintf("Unknown Datafy region %q, expected one of: %s.", region, strings.Join(datafy.Regions(), ", ")),
+				)
+				return "", diags
+			}
+			endpoint = regionEndpoint
+		}
+	}
+
+	normalized, err := datafy.ParseEndpoint(endpoint, config.AllowInsecureEndpoint.ValueBool())
+	if errors.Is(err, datafy.ErrInsecureEndpoint) {
+		diags.AddAttributeError(
+			attribute,
+			"Insecure Endpoint",
+			fmt.Sprintf("The Datafy API endpoint %q does not use HTTPS, so the token would be sent unencrypted. "+
+				"Use an https:// endpoint, or set allow_insecure_endpoint = true, e.g. for a local test server.", endpoint),
+		)
+		return "", diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			attribute,
+			"Invalid Endpoint",
+			fmt.Sprintf("Cannot use %q as the Datafy API endpoint: %s", endpoint, err.Error()),
+		)
+		return "", diags
+	}
+
+	return normalized, diags
+}
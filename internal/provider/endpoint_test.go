@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEndpoint(t *testing.T) {
+	cases := []struct {
+		name      string
+		endpoint  string
+		region    string
+		envRegion string
+		insecure  bool
+		expected  string
+	}{
+		{
+			name:     "default",
+			expected: "https://api.datafy.io",
+		},
+		{
+			name:     "trailing slash",
+			endpoint: "https://api.datafy.io/",
+			expected: "https://api.datafy.io",
+		},
+		{
+			name:     "region",
+			region:   "us",
+			expected: "https://api.datafy.io",
+		},
+		{
+			name:      "DATAFY_REGION",
+			envRegion: "us",
+			expected:  "https://api.datafy.io",
+		},
+		{
+			name:      "endpoint over DATAFY_REGION",
+			endpoint:  "https://datafy.internal.example.com",
+			envRegion: "us",
+			expected:  "https://datafy.internal.example.com",
+		},
+		{
+			name:     "allow_insecure_endpoint",
+			endpoint: "http://localhost:8080/",
+			insecure: true,
+			expected: "http://localhost:8080",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("DATAFY_REGION", tc.envRegion)
+			config := DatafyProviderConfig{
+				Region:                types.StringNull(),
+				AllowInsecureEndpoint: types.BoolValue(tc.insecure),
+			}
+			if tc.region != "" {
+				config.Region = types.StringValue(tc.region)
+			}
+
+			endpoint, diags := resolveEndpoint(config, tc.endpoint)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.expected, endpoint)
+		})
+	}
+}
+
+func TestConfigure_endpointErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		attrs    map[string]tftypes.Value
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "undocumented region",
+			attrs:    map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "eu")},
+			expected: "Invalid Region",
+		},
+		{
+			name:     "unknown region",
+			attrs:    map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "ap")},
+			expected: "Invalid Region",
+		},
+		{
+			name:     "unknown DATAFY_REGION",
+			env:      map[string]string{"DATAFY_REGION": "ap"},
+			expected: "Invalid Region",
+		},
+		{
+			name:     "http endpoint",
+			attrs:    map[string]tftypes.Value{"endpoint": tftypes.NewValue(tftypes.String, "http://api.datafy.io")},
+			expected: "Insecure Endpoint",
+		},
+		{
+			name:     "http DATAFY_ENDPOINT",
+			env:      map[string]string{"DATAFY_ENDPOINT": "http://api.datafy.io"},
+			expected: "Insecure Endpoint",
+		},
+		{
+			name:     "endpoint without scheme",
+			attrs:    map[string]tftypes.Value{"endpoint": tftypes.NewValue(tftypes.String, "api.datafy.io")},
+			expected: "Invalid Endpoint",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isolateCredentials(t)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			attrs := map[string]tftypes.Value{
+				"token": tftypes.NewValue(tftypes.String, "token"),
+			}
+			for name, v := range tc.attrs {
+				attrs[name] = v
+			}
+
+			resp := configure(t, attrs)
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expected, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestValidateConfig_endpointAndRegion(t *testing.T) {
+	ctx := context.Background()
+
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	validate := func(attrs map[string]tftypes.Value) *provider.ValidateConfigResponse {
+		objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		values := map[string]tftypes.Value{}
+		for name, typ := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(typ, nil)
+		}
+		for name, v := range attrs {
+			values[name] = v
+		}
+
+		resp := &provider.ValidateConfigResponse{}
+		p.(provider.ProviderWithValidateConfig).ValidateConfig(ctx, provider.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+		}, resp)
+		return resp
+	}
+
+	resp := validate(map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://datafy.internal.example.com"),
+		"region":   tftypes.NewValue(tftypes.String, "us"),
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Conflicting Endpoint Configuration", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = validate(map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://datafy.internal.example.com"),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
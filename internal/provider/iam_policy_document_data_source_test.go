@@ -0,0 +1,109 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/iampolicy"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIamPolicyDocumentDataSource_basic(t *testing.T) {
+	resourceName := "data.datafy_iam_policy_document.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIamPolicyDocumentDataSourceConfig("read_only"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "feature_set", "read_only"),
+					resource.TestCheckResourceAttrPair(resourceName, "external_id", "datafy_account.test", "external_id"),
+					resource.TestMatchResourceAttr(resourceName, "trust_policy_json", regexp.MustCompile(`sts:ExternalId`)),
+					resource.TestMatchResourceAttr(resourceName, "permissions_policy_json", regexp.MustCompile(`ec2:DescribeVolumes`)),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_version"),
+					resource.TestCheckResourceAttr(resourceName, "partition", "aws"),
+					resource.TestCheckResourceAttr(resourceName, "principal_arn", "arn:aws:iam::111122223333:root"),
+				),
+			},
+			{
+				Config: testAccIamPolicyDocumentDataSourceConfig("autoscaling"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "feature_set", "autoscaling"),
+					resource.TestMatchResourceAttr(resourceName, "permissions_policy_json", regexp.MustCompile(`ec2:ModifyVolume`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIamPolicyDocumentDataSource_defaultPrincipal(t *testing.T) {
+	principal, ok := iampolicy.Principals["aws"]
+	if !ok {
+		t.Skip("iampolicy.Principals has no Datafy principal for the aws partition")
+	}
+	resourceName := "data.datafy_iam_policy_document.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "datafy_iam_policy_document" "test" {
+  external_id = "ext-456"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "partition", "aws"),
+					resource.TestCheckResourceAttr(resourceName, "principal_arn", principal),
+					resource.TestMatchResourceAttr(resourceName, "trust_policy_json", regexp.MustCompile(`"AWS": "`+regexp.QuoteMeta(principal)+`"`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIamPolicyDocumentDataSource_invalidFeatureSet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIamPolicyDocumentDataSourceConfig("full_access"),
+				ExpectError: regexp.MustCompile(`Invalid Feature Set`),
+			},
+		},
+	})
+}
+
+func TestAccIamPolicyDocumentDataSource_invalidPartition(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "datafy_iam_policy_document" "test" {
+  external_id = "ext-456"
+  partition   = "aws-iso"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Partition`),
+			},
+		},
+	})
+}
+
+func testAccIamPolicyDocumentDataSourceConfig(featureSet string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = "regression-test-policy-document"
+  deletion_protection = false
+}
+
+data "datafy_iam_policy_document" "test" {
+  account_id    = datafy_account.test.id
+  principal_arn = "arn:aws:iam::111122223333:root"
+  feature_set   = %[1]q
+}
+`, featureSet)
+}
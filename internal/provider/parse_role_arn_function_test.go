@@ -0,0 +1,56 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParseRoleArnFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::datafy::parse_role_arn("arn:aws-us-gov:iam::123456789012:role/service-role/DatafyRole")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"partition":  knownvalue.StringExact("aws-us-gov"),
+						"account_id": knownvalue.StringExact("123456789012"),
+						"path":       knownvalue.StringExact("/service-role/"),
+						"name":       knownvalue.StringExact("DatafyRole"),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestAccParseRoleArnFunction_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::datafy::parse_role_arn("arn:aws:iam::1234567890123:role/DatafyRole")
+}
+`,
+				ExpectError: regexp.MustCompile(`must be exactly 12 digits`),
+			},
+		},
+	})
+}
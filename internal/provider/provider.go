@@ -2,14 +2,21 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
 	"github.com/datafy-io/terraform-provider-datafy/internal/service/account"
 	"github.com/datafy-io/terraform-provider-datafy/internal/service/autoscaling_rule"
+	"github.com/datafy-io/terraform-provider-datafy/internal/service/autoscaling_rules"
+	"github.com/datafy-io/terraform-provider-datafy/internal/service/identity"
+	"github.com/datafy-io/terraform-provider-datafy/internal/service/policydocument"
 	"github.com/datafy-io/terraform-provider-datafy/internal/service/rolearn"
 	"github.com/datafy-io/terraform-provider-datafy/internal/service/token"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -18,15 +25,36 @@ import (
 )
 
 // Ensure DatafyProvider satisfies provider interfaces.
-var _ provider.Provider = &DatafyProvider{}
+var (
+	_ provider.Provider                   = &DatafyProvider{}
+	_ provider.ProviderWithFunctions      = &DatafyProvider{}
+	_ provider.ProviderWithValidateConfig = &DatafyProvider{}
+)
 
 type DatafyProvider struct {
 	version string
 }
 
 type DatafyProviderConfig struct {
-	Token    types.String `tfsdk:"token"`
-	Endpoint types.String `tfsdk:"endpoint"`
+	Token                 types.String `tfsdk:"token"`
+	TokenFile             types.String `tfsdk:"token_file"`
+	Profile               types.String `tfsdk:"profile"`
+	CredentialProcess     types.String `tfsdk:"credential_process"`
+	ClientId              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	TokenURL              types.String `tfsdk:"token_url"`
+	CACertFile            types.String `tfsdk:"ca_cert_file"`
+	CACertPEM             types.String `tfsdk:"ca_cert_pem"`
+	ClientCert            types.String `tfsdk:"client_cert"`
+	ClientKey             types.String `tfsdk:"client_key"`
+	ProxyURL              types.String `tfsdk:"proxy_url"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	Region                types.String `tfsdk:"region"`
+	AllowInsecureEndpoint types.Bool   `tfsdk:"allow_insecure_endpoint"`
+	DefaultAccountId      types.String `tfsdk:"default_account_id"`
+	DefaultLabels         types.Map    `tfsdk:"default_labels"`
+	VerifyCredentials     types.Bool   `tfsdk:"verify_credentials"`
 }
 
 func (p *DatafyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -39,18 +67,108 @@ func (p *DatafyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 		Description: "The Datafy provider is used to manage Datafy accounts, IAM role associations, access tokens, and autoscaling rules. For more information, see the [Datafy documentation](https://docs.datafy.io).",
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				Description: "Datafy API token used for authentication. Can also be configured using the `DATAFY_TOKEN` environment variable. See [Token Generation](https://docs.datafy.io/set-up-and-installation/datafy-installation/token-generation) for instructions on creating a token.",
+				Description: "Datafy API token used for authentication. Can also be configured using the `DATAFY_TOKEN` environment variable, `token_file` or `profile`. See [Token Generation](https://docs.datafy.io/set-up-and-installation/datafy-installation/token-generation) for instructions on creating a token.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token_file": schema.StringAttribute{
+				Description: "Path to a file containing the Datafy API token, such as a mounted Kubernetes secret. Can also be configured using the `DATAFY_TOKEN_FILE` environment variable. Conflicts with `token` and `credential_process`.",
+				Optional:    true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the shared credentials file, `~/.datafy/credentials`, to read the token and endpoint from. Can also be configured using the `DATAFY_PROFILE` environment variable. Defaults to `default`.",
+				Optional:    true,
+			},
+			"credential_process": schema.StringAttribute{
+				Description: "Command that prints a JSON object with a Datafy API `token`, and optionally its `expires_at` time in RFC 3339 format, to stdout. The command is run with the system shell when the provider is configured and again shortly before the token expires or after the API rejects it. Conflicts with `token` and `token_file`.",
+				Optional:    true,
+			},
+			"client_id": schema.StringAttribute{
+				Description: "OAuth2 client ID used to obtain short-lived access tokens with the client credentials grant. Can also be configured using the `DATAFY_CLIENT_ID` environment variable. Requires `client_secret` and `token_url`, and conflicts with `token`, `token_file` and `credential_process`.",
+				Optional:    true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description: "OAuth2 client secret for `client_id`. Can also be configured using the `DATAFY_CLIENT_SECRET` environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"token_url": schema.StringAttribute{
+				Description: "URL of the OAuth2 token endpoint that issues access tokens for `client_id`. Can also be configured using the `DATAFY_TOKEN_URL` environment variable. Must use HTTPS unless `allow_insecure_endpoint` is set.",
+				Optional:    true,
+			},
 			"endpoint": schema.StringAttribute{
-				Description: "Datafy API endpoint. Can also be configured using the `DATAFY_ENDPOINT` environment variable. Defaults to `https://api.datafy.io`.",
+				Description: "Datafy API endpoint. Can also be configured using the `DATAFY_ENDPOINT` environment variable or a profile in the shared credentials file. Must use HTTPS unless `allow_insecure_endpoint` is set. Defaults to the endpoint of `region`, `https://api.datafy.io`.",
+				Optional:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "Datafy region whose API endpoint to use when no `endpoint` is configured. The only region is `us`. Conflicts with `endpoint`. Can also be configured using the `DATAFY_REGION` environment variable. Defaults to `us`.",
+				Optional:    true,
+			},
+			"allow_insecure_endpoint": schema.BoolAttribute{
+				Description: "Whether to allow an `endpoint` or `token_url` that uses `http://` instead of `https://`, e.g. for a local test server. The token or client secret is then sent unencrypted. Defaults to `false`.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file with PEM encoded CA certificates to trust in addition to the system's, e.g. the CA of a TLS-intercepting proxy.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates to trust in addition to the system's. Can be combined with `ca_cert_file`.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate for mutual TLS, e.g. `file(\"client.pem\")`. Requires `client_key`.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of `client_cert`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an `http`, `https` or `socks5` proxy to send requests through. Defaults to the proxy set by the `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "**INSECURE.** Whether to skip verification of the TLS certificate presented by the Datafy API and the OAuth2 token endpoint. This allows anyone on the network path to intercept the token and API traffic; use `ca_cert_file` or `ca_cert_pem` to trust a private CA instead. Only intended for testing. Defaults to `false`.",
+				Optional:    true,
+			},
+			"default_account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account that resources without an `account_id` belong to. Can also be configured using the `DATAFY_ACCOUNT_ID` environment variable.",
+				Optional:    true,
+			},
+			"default_labels": schema.MapAttribute{
+				Description: "Labels merged into the labels of every `datafy_account` managed by this provider. Labels set on an account override default labels with the same key.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"verify_credentials": schema.BoolAttribute{
+				Description: "Whether to verify the token with the Datafy API when the provider is configured, so that an invalid or expired token fails before any resource is planned or applied. Defaults to `false`.",
 				Optional:    true,
 			},
 		},
 	}
 }
 
+func (p *DatafyProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config DatafyProviderConfig
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The endpoint takes precedence over the region, so configuring both
+	// silently ignores the region.
+	if !config.Endpoint.IsNull() && !config.Region.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Conflicting Endpoint Configuration",
+			"Only one of endpoint and region can be set. Remove region, or remove endpoint to use the endpoint of the region.",
+		)
+	}
+}
+
 func (p *DatafyProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var config DatafyProviderConfig
 
@@ -67,6 +185,54 @@ func (p *DatafyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.TokenFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Unknown Token File",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Profile",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.CredentialProcess.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Unknown Credential Process",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.ClientId.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_id"),
+			"Unknown Client ID",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.ClientSecret.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_secret"),
+			"Unknown Client Secret",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.TokenURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_url"),
+			"Unknown Token URL",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
 	if config.Endpoint.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
@@ -75,38 +241,159 @@ func (p *DatafyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
-	datafyToken := os.Getenv("DATAFY_TOKEN")
-	if !config.Token.IsNull() {
-		datafyToken = config.Token.ValueString()
+	if config.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown CA Certificate File",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
 	}
 
-	datafyEndpoint := os.Getenv("DATAFY_ENDPOINT")
-	if !config.Endpoint.IsNull() {
-		datafyEndpoint = config.Endpoint.ValueString()
+	if config.CACertPEM.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Unknown CA Certificate",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
 	}
-	if datafyEndpoint == "" {
-		datafyEndpoint = "https://api.datafy.io"
+
+	if config.ClientCert.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Unknown Client Certificate",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
 	}
 
-	if datafyToken == "" {
+	if config.ClientKey.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("token"),
-			"Missing Token",
-			"Cannot create Datafy Provider as there is a missing or empty value.",
+			path.Root("client_key"),
+			"Unknown Client Key",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown Proxy URL",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown Insecure Skip Verify",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
 		)
 	}
 
-	client := datafy.NewClient(datafyToken, datafyEndpoint)
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if config.Region.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Unknown Region",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.AllowInsecureEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_insecure_endpoint"),
+			"Unknown Allow Insecure Endpoint",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.DefaultAccountId.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_account_id"),
+			"Unknown Default Account ID",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_labels"),
+			"Unknown Default Labels",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	if config.VerifyCredentials.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("verify_credentials"),
+			"Unknown Verify Credentials",
+			"Cannot create Datafy Provider as there is an unknown configuration value.",
+		)
+	}
+
+	defaultLabels := map[string]string{}
+	if !config.DefaultLabels.IsNull() && !config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)...)
+	}
+
+	defaultAccountId := os.Getenv("DATAFY_ACCOUNT_ID")
+	if !config.DefaultAccountId.IsNull() {
+		defaultAccountId = config.DefaultAccountId.ValueString()
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpClient, diags := newHTTPClient(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenSource, datafyEndpoint, source, diags := resolveCredentials(ctx, config, httpClient)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := datafy.NewClientWithTokenSource(tokenSource, datafyEndpoint, httpClient)
+
+	if config.VerifyCredentials.ValueBool() {
+		gcir, err := client.GetCallerIdentity(ctx, &datafy.GetCallerIdentityRequest{})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(source.attribute),
+				"Invalid Credentials",
+				fmt.Sprintf("The Datafy API at %s rejected the token from %s: %s. Check that it is a valid token that has not expired.", datafyEndpoint, source.description, err.Error()),
+			)
+			return
+		}
+		if expires := gcir.CallerIdentity.Expires; expires != nil && !expires.After(time.Now()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(source.attribute),
+				"Expired Credentials",
+				fmt.Sprintf("The token %s from %s expired at %s.", gcir.CallerIdentity.TokenId, source.description, expires.Format(time.RFC3339)),
+			)
+			return
+		}
+	}
+	data := &providerdata.ProviderData{
+		Client:           client,
+		DefaultAccountId: defaultAccountId,
+		DefaultLabels:    defaultLabels,
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 }
 
 func (p *DatafyProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		account.NewResource,
+		account.NewSettingsResource,
 		rolearn.NewResource,
+		rolearn.NewAssociationResource,
 		token.NewResource,
 		autoscaling_rule.NewResource,
+		autoscaling_rules.NewResource,
 	}
 }
 
@@ -116,6 +403,15 @@ func (p *DatafyProvider) DataSources(ctx context.Context) []func() datasource.Da
 		rolearn.NewDataSource,
 		token.NewDataSource,
 		autoscaling_rule.NewDataSource,
+		autoscaling_rule.NewPreviewDataSource,
+		policydocument.NewDataSource,
+		identity.NewDataSource,
+	}
+}
+
+func (p *DatafyProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		rolearn.NewParseRoleArnFunction,
 	}
 }
 
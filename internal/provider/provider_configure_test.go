@@ -0,0 +1,602 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configure runs DatafyProvider.Configure with the given attributes set and
+// all others null.
+func configure(t *testing.T, attrs map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, v := range attrs {
+		values[name] = v
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, values),
+		},
+	}, resp)
+	return resp
+}
+
+func newWhoamiServer(t *testing.T, status int, body interface{}) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/whoami" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestConfigure_verifyCredentials(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	ts := newWhoamiServer(t, http.StatusOK, datafy.CallerIdentity{AccountId: "acc-123", TokenId: "tok-abc", Expires: &expires})
+
+	resp := configure(t, map[string]tftypes.Value{
+		"token":                   tftypes.NewValue(tftypes.String, "valid"),
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+		"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.NotNil(t, resp.ResourceData)
+}
+
+func TestConfigure_verifyCredentialsRejected(t *testing.T) {
+	ts := newWhoamiServer(t, http.StatusUnauthorized, map[string]string{"message": "invalid token"})
+
+	resp := configure(t, map[string]tftypes.Value{
+		"token":                   tftypes.NewValue(tftypes.String, "invalid"),
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+		"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Credentials", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "rejected the token from the token attribute: ")
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "status code 401: invalid token")
+}
+
+func TestConfigure_verifyCredentialsRejectedSource(t *testing.T) {
+	ts := newWhoamiServer(t, http.StatusUnauthorized, map[string]string{"message": "invalid token"})
+
+	cases := []struct {
+		name      string
+		env       map[string]string
+		profile   string
+		attribute string
+		source    string
+	}{
+		{
+			name:      "environment",
+			env:       map[string]string{"DATAFY_TOKEN": "invalid"},
+			attribute: "token",
+			source:    "the DATAFY_TOKEN environment variable",
+		},
+		{
+			name:      "profile",
+			profile:   "[staging]\ntoken = invalid\n",
+			env:       map[string]string{"DATAFY_PROFILE": "staging"},
+			attribute: "profile",
+			source:    `the profile "staging" in the shared credentials file`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			credentialsFile := isolateCredentials(t)
+			if tc.profile != "" {
+				writeTestFile(t, credentialsFile, tc.profile)
+			}
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			resp := configure(t, map[string]tftypes.Value{
+				"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+				"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+				"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+			})
+
+			require.True(t, resp.Diagnostics.HasError())
+			err := resp.Diagnostics.Errors()[0]
+			assert.Equal(t, "Invalid Credentials", err.Summary())
+			assert.Contains(t, err.Detail(), "rejected the token from "+tc.source+": ")
+			assert.NotContains(t, err.Detail(), "token attribute")
+			if withPath, ok := err.(diag.DiagnosticWithPath); assert.True(t, ok) {
+				assert.Equal(t, path.Root(tc.attribute), withPath.Path())
+			}
+		})
+	}
+}
+
+func TestConfigure_verifyCredentialsUnknown(t *testing.T) {
+	resp := configure(t, map[string]tftypes.Value{
+		"token":              tftypes.NewValue(tftypes.String, "valid"),
+		"verify_credentials": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+	})
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Unknown Verify Credentials", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestConfigure_verifyCredentialsExpired(t *testing.T) {
+	expires := time.Now().Add(-time.Hour)
+	ts := newWhoamiServer(t, http.StatusOK, datafy.CallerIdentity{AccountId: "acc-123", TokenId: "tok-abc", Expires: &expires})
+
+	resp := configure(t, map[string]tftypes.Value{
+		"token":                   tftypes.NewValue(tftypes.String, "expired"),
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+		"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Expired Credentials", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestConfigure_noVerification(t *testing.T) {
+	// Without verify_credentials the API is not called.
+	ts := newWhoamiServer(t, http.StatusUnauthorized, map[string]string{"message": "invalid token"})
+
+	resp := configure(t, map[string]tftypes.Value{
+		"token":                   tftypes.NewValue(tftypes.String, "invalid"),
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
+
+func TestConfigure_defaultAccountId(t *testing.T) {
+	t.Setenv("DATAFY_ACCOUNT_ID", "acc-env")
+
+	resp := configure(t, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "token"),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "acc-env", resp.ResourceData.(*providerdata.ProviderData).DefaultAccountId)
+
+	// The provider configuration takes precedence over the environment.
+	resp = configure(t, map[string]tftypes.Value{
+		"token":              tftypes.NewValue(tftypes.String, "token"),
+		"default_account_id": tftypes.NewValue(tftypes.String, "acc-config"),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "acc-config", resp.ResourceData.(*providerdata.ProviderData).DefaultAccountId)
+}
+
+// newTokenRecorder returns a whoami server that accepts any token and records
+// the last one it received.
+func newTokenRecorder(t *testing.T) (*httptest.Server, *string) {
+	var token string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(datafy.CallerIdentity{AccountId: "acc-123"})
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &token
+}
+
+// isolateCredentials clears the credential environment variables and points
+// HOME at an empty directory, returning the path of the shared credentials
+// file in it.
+func isolateCredentials(t *testing.T) string {
+	t.Helper()
+	for _, name := range []string{"DATAFY_TOKEN", "DATAFY_TOKEN_FILE", "DATAFY_PROFILE", "DATAFY_ENDPOINT", "DATAFY_CLIENT_ID", "DATAFY_CLIENT_SECRET", "DATAFY_TOKEN_URL", "DATAFY_REGION"} {
+		t.Setenv(name, "")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return filepath.Join(home, ".datafy", "credentials")
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestConfigure_credentialsPrecedence(t *testing.T) {
+	ts, gotToken := newTokenRecorder(t)
+	unused := "http://127.0.0.1:1"
+
+	cases := []struct {
+		name     string
+		env      map[string]string
+		attrs    map[string]string
+		expected string
+	}{
+		{
+			name:     "default profile",
+			expected: "default-token",
+		},
+		{
+			name:     "DATAFY_PROFILE",
+			env:      map[string]string{"DATAFY_PROFILE": "staging"},
+			expected: "staging-token",
+		},
+		{
+			name:     "DATAFY_TOKEN_FILE over profile",
+			env:      map[string]string{"DATAFY_PROFILE": "staging", "DATAFY_TOKEN_FILE": "env-file"},
+			expected: "env-file-token",
+		},
+		{
+			name:     "DATAFY_TOKEN over DATAFY_TOKEN_FILE",
+			env:      map[string]string{"DATAFY_TOKEN": "env-token", "DATAFY_TOKEN_FILE": "env-file"},
+			expected: "env-token",
+		},
+		{
+			name:     "profile attribute over DATAFY_TOKEN",
+			env:      map[string]string{"DATAFY_TOKEN": "env-token"},
+			attrs:    map[string]string{"profile": "staging"},
+			expected: "staging-token",
+		},
+		{
+			name:     "token_file attribute over profile attribute",
+			env:      map[string]string{"DATAFY_TOKEN": "env-token"},
+			attrs:    map[string]string{"profile": "staging", "token_file": "config-file"},
+			expected: "config-file-token",
+		},
+		{
+			name:     "token attribute over profile attribute",
+			env:      map[string]string{"DATAFY_TOKEN": "env-token"},
+			attrs:    map[string]string{"profile": "staging", "token": "config-token"},
+			expected: "config-token",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			credentialsFile := isolateCredentials(t)
+			dir := t.TempDir()
+			writeTestFile(t, filepath.Join(dir, "env-file"), "env-file-token\n")
+			writeTestFile(t, filepath.Join(dir, "config-file"), "config-file-token\n")
+			writeTestFile(t, credentialsFile, fmt.Sprintf(`
+[default]
+token    = "default-token"
+endpoint = "%[1]s"
+
+[staging]
+token    = "staging-token"
+endpoint = "%[1]s"
+`, ts.URL))
+
+			// Tokens that do not come from a profile use DATAFY_ENDPOINT.
+			t.Setenv("DATAFY_ENDPOINT", ts.URL)
+			for name, value := range tc.env {
+				if name == "DATAFY_TOKEN_FILE" {
+					value = filepath.Join(dir, value)
+				}
+				t.Setenv(name, value)
+			}
+			// The stub server does not use HTTPS.
+			attrs := map[string]tftypes.Value{
+				"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+				"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+			}
+			for name, value := range tc.attrs {
+				if name == "token_file" {
+					value = filepath.Join(dir, value)
+				}
+				attrs[name] = tftypes.NewValue(tftypes.String, value)
+			}
+
+			*gotToken = ""
+			resp := configure(t, attrs)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, tc.expected, *gotToken)
+		})
+	}
+
+	t.Run("profile endpoint", func(t *testing.T) {
+		credentialsFile := isolateCredentials(t)
+		writeTestFile(t, credentialsFile, fmt.Sprintf("[default]\ntoken = default-token\nendpoint = %s\n", ts.URL))
+
+		// The endpoint of the profile that supplied the token takes
+		// precedence over DATAFY_ENDPOINT.
+		t.Setenv("DATAFY_ENDPOINT", unused)
+		*gotToken = ""
+		resp := configure(t, map[string]tftypes.Value{
+			"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+			"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+		})
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.Equal(t, "default-token", *gotToken)
+	})
+
+	t.Run("endpoint attribute", func(t *testing.T) {
+		credentialsFile := isolateCredentials(t)
+		writeTestFile(t, credentialsFile, fmt.Sprintf("[default]\ntoken = default-token\nendpoint = %s\n", unused))
+
+		// The endpoint attribute takes precedence over the profile and the
+		// environment.
+		t.Setenv("DATAFY_ENDPOINT", unused)
+		*gotToken = ""
+		resp := configure(t, map[string]tftypes.Value{
+			"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+			"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+			"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+		})
+		assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.Equal(t, "default-token", *gotToken)
+	})
+}
+
+func TestResolveCredentials_profileEndpointWithEnvToken(t *testing.T) {
+	credentialsFile := isolateCredentials(t)
+	writeTestFile(t, credentialsFile, "[default]\ntoken = staging-token\nendpoint = https://api.staging.example.com\n")
+	t.Setenv("DATAFY_TOKEN", "production-token")
+
+	// The default profile's endpoint is not used with the token from
+	// DATAFY_TOKEN.
+	tokenSource, endpoint, _, diags := resolveCredentials(context.Background(), DatafyProviderConfig{}, nil)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "https://api.datafy.io", endpoint)
+	token, err := tokenSource.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "production-token", token)
+
+	// Nor is the profile selected by DATAFY_PROFILE's.
+	t.Setenv("DATAFY_PROFILE", "default")
+	t.Setenv("DATAFY_ENDPOINT", "https://api.eu.datafy.io")
+	_, endpoint, _, diags = resolveCredentials(context.Background(), DatafyProviderConfig{}, nil)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "https://api.eu.datafy.io", endpoint)
+}
+
+func TestConfigure_credentialsErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		attrs    map[string]tftypes.Value
+		expected string
+	}{
+		{
+			name:     "no credentials file",
+			expected: "Missing Token",
+		},
+		{
+			name:     "profile attribute not found",
+			attrs:    map[string]tftypes.Value{"profile": tftypes.NewValue(tftypes.String, "prod")},
+			expected: "Invalid Profile",
+		},
+		{
+			name:     "DATAFY_PROFILE not found",
+			env:      map[string]string{"DATAFY_PROFILE": "prod"},
+			expected: "Invalid Profile",
+		},
+		{
+			name: "token and token_file",
+			attrs: map[string]tftypes.Value{
+				"token":      tftypes.NewValue(tftypes.String, "config-token"),
+				"token_file": tftypes.NewValue(tftypes.String, "/nonexistent"),
+			},
+			expected: "Conflicting Token Configuration",
+		},
+		{
+			name: "token and credential_process",
+			attrs: map[string]tftypes.Value{
+				"token":              tftypes.NewValue(tftypes.String, "config-token"),
+				"credential_process": tftypes.NewValue(tftypes.String, "true"),
+			},
+			expected: "Conflicting Token Configuration",
+		},
+		{
+			name: "token and client credentials",
+			attrs: map[string]tftypes.Value{
+				"token":         tftypes.NewValue(tftypes.String, "config-token"),
+				"client_id":     tftypes.NewValue(tftypes.String, "client-id"),
+				"client_secret": tftypes.NewValue(tftypes.String, "client-secret"),
+				"token_url":     tftypes.NewValue(tftypes.String, "http://127.0.0.1:1/oauth/token"),
+			},
+			expected: "Conflicting Token Configuration",
+		},
+		{
+			name: "DATAFY_TOKEN and client credentials",
+			env:  map[string]string{"DATAFY_TOKEN": "env-token", "DATAFY_CLIENT_SECRET": "client-secret"},
+			attrs: map[string]tftypes.Value{
+				"client_id": tftypes.NewValue(tftypes.String, "client-id"),
+				"token_url": tftypes.NewValue(tftypes.String, "http://127.0.0.1:1/oauth/token"),
+			},
+			expected: "Conflicting Token Configuration",
+		},
+		{
+			name:     "client_id without client_secret",
+			attrs:    map[string]tftypes.Value{"client_id": tftypes.NewValue(tftypes.String, "client-id"), "token_url": tftypes.NewValue(tftypes.String, "http://127.0.0.1:1/oauth/token")},
+			expected: "Missing Client Secret",
+		},
+		{
+			name:     "client credentials without token_url",
+			env:      map[string]string{"DATAFY_CLIENT_ID": "client-id", "DATAFY_CLIENT_SECRET": "client-secret"},
+			expected: "Missing Token URL",
+		},
+		{
+			name:     "insecure token_url",
+			env:      map[string]string{"DATAFY_CLIENT_ID": "client-id", "DATAFY_CLIENT_SECRET": "client-secret"},
+			attrs:    map[string]tftypes.Value{"token_url": tftypes.NewValue(tftypes.String, "http://auth.example.com/oauth/token")},
+			expected: "Insecure Token URL",
+		},
+		{
+			name:     "invalid token_url",
+			env:      map[string]string{"DATAFY_CLIENT_ID": "client-id", "DATAFY_CLIENT_SECRET": "client-secret", "DATAFY_TOKEN_URL": "auth.example.com/oauth/token"},
+			expected: "Invalid Token URL",
+		},
+		{
+			name:     "credential_process failed",
+			attrs:    map[string]tftypes.Value{"credential_process": tftypes.NewValue(tftypes.String, "exit 1")},
+			expected: "Credential Process Failed",
+		},
+		{
+			name:     "token_file not found",
+			attrs:    map[string]tftypes.Value{"token_file": tftypes.NewValue(tftypes.String, "/nonexistent")},
+			expected: "Invalid Token File",
+		},
+		{
+			name:     "DATAFY_TOKEN_FILE not found",
+			env:      map[string]string{"DATAFY_TOKEN_FILE": "/nonexistent"},
+			expected: "Invalid Token File",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isolateCredentials(t)
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+
+			resp := configure(t, tc.attrs)
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expected, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+
+	t.Run("malformed default profile", func(t *testing.T) {
+		writeTestFile(t, isolateCredentials(t), "token = outside-section\n")
+
+		resp := configure(t, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "config-token"),
+		})
+		assert.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Invalid Profile", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
+
+func TestConfigure_credentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command needs a POSIX shell")
+	}
+	ts, gotToken := newTokenRecorder(t)
+	isolateCredentials(t)
+	// credential_process takes precedence over the environment.
+	t.Setenv("DATAFY_TOKEN", "env-token")
+
+	resp := configure(t, map[string]tftypes.Value{
+		"credential_process":      tftypes.NewValue(tftypes.String, `echo '{"token": "process-token"}'`),
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+		"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "process-token", *gotToken)
+}
+
+// newOAuth2TokenEndpoint returns a stub OAuth2 token endpoint that issues
+// access-token to client-id/client-secret.
+func newOAuth2TokenEndpoint(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client-id" || secret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestConfigure_clientCredentials(t *testing.T) {
+	ts, gotToken := newTokenRecorder(t)
+	tokenEndpoint := newOAuth2TokenEndpoint(t)
+	isolateCredentials(t)
+
+	resp := configure(t, map[string]tftypes.Value{
+		"client_id":               tftypes.NewValue(tftypes.String, "client-id"),
+		"client_secret":           tftypes.NewValue(tftypes.String, "client-secret"),
+		"token_url":               tftypes.NewValue(tftypes.String, tokenEndpoint.URL),
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+		"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "access-token", *gotToken)
+
+	// The client credentials can also be set in the environment.
+	t.Setenv("DATAFY_CLIENT_ID", "client-id")
+	t.Setenv("DATAFY_CLIENT_SECRET", "client-secret")
+	t.Setenv("DATAFY_TOKEN_URL", tokenEndpoint.URL)
+	*gotToken = ""
+	resp = configure(t, map[string]tftypes.Value{
+		"endpoint":                tftypes.NewValue(tftypes.String, ts.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+		"verify_credentials":      tftypes.NewValue(tftypes.Bool, true),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "access-token", *gotToken)
+}
+
+func TestConfigure_clientCredentialsErrors(t *testing.T) {
+	tokenEndpoint := newOAuth2TokenEndpoint(t)
+	isolateCredentials(t)
+
+	resp := configure(t, map[string]tftypes.Value{
+		"client_id":               tftypes.NewValue(tftypes.String, "client-id"),
+		"client_secret":           tftypes.NewValue(tftypes.String, "wrong-secret"),
+		"token_url":               tftypes.NewValue(tftypes.String, tokenEndpoint.URL),
+		"allow_insecure_endpoint": tftypes.NewValue(tftypes.Bool, true),
+	})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Client Credentials", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "invalid_client")
+
+	t.Setenv("DATAFY_TOKEN", "env-token")
+	resp = configure(t, map[string]tftypes.Value{
+		"client_id":     tftypes.NewValue(tftypes.String, "client-id"),
+		"client_secret": tftypes.NewValue(tftypes.String, "client-secret"),
+		"token_url":     tftypes.NewValue(tftypes.String, tokenEndpoint.URL),
+	})
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Conflicting Token Configuration", resp.Diagnostics.Errors()[0].Summary())
+	assert.Equal(t, "A token and OAuth2 client credentials cannot both be set. The token is set by DATAFY_TOKEN, "+
+		"and the client credentials by client_id and client_secret. Remove either the token or the client credentials.",
+		resp.Diagnostics.Errors()[0].Detail())
+}
@@ -4,8 +4,16 @@ import (
 	"github.com/datafy-io/terraform-provider-datafy/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"datafy": providerserver.NewProtocol6WithError(provider.New("test")()),
 }
+
+// testAccWriteOnlyVersionChecks skips tests whose configuration sets a
+// write-only attribute, such as skip_validation, on Terraform versions that
+// do not support write-only attributes.
+var testAccWriteOnlyVersionChecks = []tfversion.TerraformVersionCheck{
+	tfversion.SkipBelow(tfversion.Version1_11_0),
+}
@@ -10,6 +10,7 @@ func TestAccRoleArnDataSource_basic(t *testing.T) {
 	resourceName := "data.datafy_role_arn.test"
 
 	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -17,6 +18,9 @@ func TestAccRoleArnDataSource_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resourceName, "account_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "arn"),
+					resource.TestCheckResourceAttrSet(resourceName, "external_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "validation_status"),
+					resource.TestCheckResourceAttrSet(resourceName, "missing_permissions.#"),
 				),
 			},
 		},
@@ -26,7 +30,8 @@ func TestAccRoleArnDataSource_basic(t *testing.T) {
 func testAccRoleArnDataSourceConfig() string {
 	return `
 resource "datafy_account" "test" {
-  name = "regression-test-rolearn-ds"
+  name                = "regression-test-rolearn-ds"
+  deletion_protection = false
 }
 
 resource "datafy_role_arn" "test" {
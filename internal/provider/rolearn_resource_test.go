@@ -3,17 +3,21 @@ package provider_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccRoleArnResource_basic(t *testing.T) {
 	resourceName := "datafy_role_arn.test"
 
 	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRoleArnDestroy,
 		Steps: []resource.TestStep{
@@ -25,7 +29,8 @@ func TestAccRoleArnResource_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resourceName, "account_id"),
 					resource.TestCheckResourceAttr(resourceName, "arn", "arn:aws:iam::123456789012:role/regression-test-role"),
-					resource.TestCheckResourceAttr(resourceName, "skip_validation", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "skip_validation"),
+					resource.TestCheckResourceAttrPair(resourceName, "external_id", "datafy_account.test", "external_id"),
 				),
 			},
 		},
@@ -36,6 +41,7 @@ func TestAccRoleArnResource_update(t *testing.T) {
 	resourceName := "datafy_role_arn.test"
 
 	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		CheckDestroy:             testAccCheckRoleArnDestroy,
 		Steps: []resource.TestStep{
@@ -61,6 +67,134 @@ func TestAccRoleArnResource_update(t *testing.T) {
 	})
 }
 
+func TestAccRoleArnResource_revalidateTriggers(t *testing.T) {
+	resourceName := "datafy_role_arn.test"
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRoleArnDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleArnResourceConfigRevalidate("v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "revalidate_triggers.policy", "v1"),
+					resource.TestCheckResourceAttrSet(resourceName, "validation_status"),
+					resource.TestCheckResourceAttrSet(resourceName, "missing_permissions.#"),
+				),
+			},
+			{
+				Config: testAccRoleArnResourceConfigRevalidate("v2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("validation_status")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "revalidate_triggers.policy", "v2"),
+					resource.TestCheckResourceAttrSet(resourceName, "validation_status"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleArnResource_skipValidationToggle(t *testing.T) {
+	resourceName := "datafy_role_arn.test"
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRoleArnDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleArnResourceConfig(
+					"arn:aws:iam::123456789012:role/regression-test-role",
+					true,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "skip_validation"),
+					resource.TestCheckResourceAttr(resourceName, "validation_status", "skipped"),
+				),
+			},
+			{
+				// The role does not exist, so turning validation on must
+				// plan an update and fail it.
+				Config: testAccRoleArnResourceConfigTimeouts(
+					"arn:aws:iam::123456789012:role/regression-test-role",
+					false,
+					"10s",
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resourceName, tfjsonpath.New("validation_status")),
+					},
+				},
+				ExpectError: regexp.MustCompile(`did not pass validation`),
+			},
+		},
+	})
+}
+
+func TestAccRoleArnResource_timeouts(t *testing.T) {
+	resourceName := "datafy_role_arn.test"
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckRoleArnDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleArnResourceConfigTimeouts(
+					"arn:aws:iam::123456789012:role/regression-test-role",
+					true,
+					"10m",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "arn", "arn:aws:iam::123456789012:role/regression-test-role"),
+					resource.TestCheckResourceAttr(resourceName, "timeouts.create", "10m"),
+					resource.TestCheckResourceAttr(resourceName, "timeouts.update", "10m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleArnResource_invalidTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleArnResourceConfigTimeouts(
+					"arn:aws:iam::123456789012:role/regression-test-role",
+					true,
+					"ten minutes",
+				),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Time Duration`),
+			},
+		},
+	})
+}
+
+func TestAccRoleArnResource_invalidArn(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks:   testAccWriteOnlyVersionChecks,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleArnResourceConfig(
+					"arn:aws:iam::123456789012:regression-test-role",
+					true,
+				),
+				ExpectError: regexp.MustCompile(`Invalid IAM Role ARN`),
+			},
+		},
+	})
+}
+
 func testAccCheckRoleArnDestroy(s *terraform.State) error {
 	client := newTestClient()
 
@@ -83,7 +217,8 @@ func testAccCheckRoleArnDestroy(s *terraform.State) error {
 func testAccRoleArnResourceConfig(arn string, skipValidation bool) string {
 	return fmt.Sprintf(`
 resource "datafy_account" "test" {
-  name = "regression-test-rolearn"
+  name                = "regression-test-rolearn"
+  deletion_protection = false
 }
 
 resource "datafy_role_arn" "test" {
@@ -93,3 +228,42 @@ resource "datafy_role_arn" "test" {
 }
 `, arn, skipValidation)
 }
+
+func testAccRoleArnResourceConfigTimeouts(arn string, skipValidation bool, timeout string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = "regression-test-rolearn"
+  deletion_protection = false
+}
+
+resource "datafy_role_arn" "test" {
+  account_id      = datafy_account.test.id
+  arn             = %[1]q
+  skip_validation = %[2]t
+
+  timeouts {
+    create = %[3]q
+    update = %[3]q
+  }
+}
+`, arn, skipValidation, timeout)
+}
+
+func testAccRoleArnResourceConfigRevalidate(trigger string) string {
+	return fmt.Sprintf(`
+resource "datafy_account" "test" {
+  name                = "regression-test-rolearn"
+  deletion_protection = false
+}
+
+resource "datafy_role_arn" "test" {
+  account_id      = datafy_account.test.id
+  arn             = "arn:aws:iam::123456789012:role/regression-test-role"
+  skip_validation = true
+
+  revalidate_triggers = {
+    policy = %q
+  }
+}
+`, trigger)
+}
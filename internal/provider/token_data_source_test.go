@@ -29,7 +29,8 @@ func TestAccTokenDataSource_basic(t *testing.T) {
 func testAccTokenDataSourceConfig() string {
 	return `
 resource "datafy_account" "test" {
-  name = "regression-test-token-ds"
+  name                = "regression-test-token-ds"
+  deletion_protection = false
 }
 
 resource "datafy_token" "test" {
@@ -3,6 +3,7 @@ package provider_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
@@ -52,6 +53,24 @@ func TestAccTokenResource_noTTL(t *testing.T) {
 	})
 }
 
+func TestAccTokenResource_missingAccountId(t *testing.T) {
+	t.Setenv("DATAFY_ACCOUNT_ID", "")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "datafy_token" "test" {
+  description = "regression-test-token-missing-account-id"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing Account ID"),
+			},
+		},
+	})
+}
+
 func testAccCheckTokenDestroy(s *terraform.State) error {
 	client := newTestClient()
 
@@ -75,7 +94,8 @@ func testAccCheckTokenDestroy(s *terraform.State) error {
 func testAccTokenResourceConfig(description, ttl string) string {
 	return fmt.Sprintf(`
 resource "datafy_account" "test" {
-  name = "regression-test-token"
+  name                = "regression-test-token"
+  deletion_protection = false
 }
 
 resource "datafy_token" "test" {
@@ -90,7 +110,8 @@ resource "datafy_token" "test" {
 func testAccTokenResourceConfigNoTTL(description string) string {
 	return fmt.Sprintf(`
 resource "datafy_account" "test" {
-  name = "regression-test-token-no-ttl"
+  name                = "regression-test-token-no-ttl"
+  deletion_protection = false
 }
 
 resource "datafy_token" "test" {
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// newHTTPClient returns the HTTP client the provider calls the Datafy API
+// and the OAuth2 token endpoint with. It uses the default transport, which
+// honors the HTTPS_PROXY and NO_PROXY environment variables, with the
+// provider's TLS and proxy attributes applied.
+func newHTTPClient(config DatafyProviderConfig) (*http.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if !config.CACertFile.IsNull() || !config.CACertPEM.IsNull() {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !config.CACertFile.IsNull() {
+			pem, err := os.ReadFile(config.CACertFile.ValueString())
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Invalid CA Certificate",
+					fmt.Sprintf("Cannot read ca_cert_file: %s", err.Error()),
+				)
+			} else if !pool.AppendCertsFromPEM(pem) {
+				diags.AddAttributeError(
+					path.Root("ca_cert_file"),
+					"Invalid CA Certificate",
+					fmt.Sprintf("%s does not contain any PEM encoded certificates.", config.CACertFile.ValueString()),
+				)
+			}
+		}
+
+		if !config.CACertPEM.IsNull() && !pool.AppendCertsFromPEM([]byte(config.CACertPEM.ValueString())) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Invalid CA Certificate",
+				"ca_cert_pem does not contain any PEM encoded certificates.",
+			)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case !config.ClientCert.IsNull() && !config.ClientKey.IsNull():
+		cert, err := tls.X509KeyPair([]byte(config.ClientCert.ValueString()), []byte(config.ClientKey.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert"),
+				"Invalid Client Certificate",
+				fmt.Sprintf("Cannot load the client certificate and key: %s", err.Error()),
+			)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case !config.ClientCert.IsNull():
+		diags.AddAttributeError(
+			path.Root("client_key"),
+			"Missing Client Key",
+			"client_key must be set together with client_cert.",
+		)
+	case !config.ClientKey.IsNull():
+		diags.AddAttributeError(
+			path.Root("client_cert"),
+			"Missing Client Certificate",
+			"client_cert must be set together with client_key.",
+		)
+	}
+
+	// Disabling verification is only meant for testing against a server
+	// with a self-signed certificate; see the attribute's description.
+	tlsConfig.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+
+	transport.TLSClientConfig = tlsConfig
+
+	if !config.ProxyURL.IsNull() {
+		proxyURL, err := url.Parse(config.ProxyURL.ValueString())
+		if err == nil && (proxyURL.Host == "" || !(proxyURL.Scheme == "http" || proxyURL.Scheme == "https" || proxyURL.Scheme == "socks5")) {
+			err = fmt.Errorf("expected an http, https or socks5 URL with a host")
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("Cannot use %q as proxy: %s", config.ProxyURL.ValueString(), err.Error()),
+			)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return datafy.NewHTTPClient(transport), diags
+}
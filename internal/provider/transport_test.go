@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA is a locally generated certificate authority.
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Datafy Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a PEM encoded certificate and key signed by the CA, for the
+// server at 127.0.0.1 or for a client.
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	if usage == x509.ExtKeyUsageServerAuth {
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// newTLSWhoamiServer starts a whoami server with a certificate issued by
+// ca. If clientCA is not nil, the server requires a client certificate
+// issued by it. The returned function reports the common name of the last
+// client certificate.
+func newTLSWhoamiServer(t *testing.T, ca *testCA, clientCA *testCA) (*httptest.Server, func() string) {
+	t.Helper()
+	var mu sync.Mutex
+	var clientName string
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			mu.Lock()
+			clientName = r.TLS.PeerCertificates[0].Subject.CommonName
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(datafy.CallerIdentity{AccountId: "acc-123"})
+	}))
+
+	certPEM, keyPEM := ca.issue(t, "127.0.0.1", x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	ts.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCA != nil {
+		pool := x509.NewCertPool()
+		pool.AddCert(clientCA.cert)
+		ts.TLS.ClientCAs = pool
+		ts.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+
+	return ts, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return clientName
+	}
+}
+
+// newConnectProxy starts an HTTP proxy that tunnels CONNECT requests and
+// returns the hosts it tunneled to.
+func newConnectProxy(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var hosts []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		mu.Lock()
+		hosts = append(hosts, r.Host)
+		mu.Unlock()
+
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			upstream.Close()
+			return
+		}
+		go func() {
+			_, _ = io.Copy(upstream, buf)
+			upstream.Close()
+		}()
+		_, _ = io.Copy(conn, upstream)
+		conn.Close()
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return hosts
+	}
+}
+
+func TestConfigure_caCert(t *testing.T) {
+	isolateCredentials(t)
+	ca := newTestCA(t)
+	ts, _ := newTLSWhoamiServer(t, ca, nil)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0o600))
+
+	cases := []struct {
+		name  string
+		attrs map[string]tftypes.Value
+		err   string
+	}{
+		{
+			name: "system roots only",
+			err:  "certificate signed by unknown authority",
+		},
+		{
+			name:  "ca_cert_file",
+			attrs: map[string]tftypes.Value{"ca_cert_file": tftypes.NewValue(tftypes.String, caFile)},
+		},
+		{
+			name:  "ca_cert_pem",
+			attrs: map[string]tftypes.Value{"ca_cert_pem": tftypes.NewValue(tftypes.String, string(ca.certPEM))},
+		},
+		{
+			name:  "insecure_skip_verify",
+			attrs: map[string]tftypes.Value{"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs := map[string]tftypes.Value{
+				"token":              tftypes.NewValue(tftypes.String, "token"),
+				"endpoint":           tftypes.NewValue(tftypes.String, ts.URL),
+				"verify_credentials": tftypes.NewValue(tftypes.Bool, true),
+			}
+			for name, v := range tc.attrs {
+				attrs[name] = v
+			}
+
+			resp := configure(t, attrs)
+			if tc.err == "" {
+				assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+				return
+			}
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tc.err)
+		})
+	}
+}
+
+func TestConfigure_clientCert(t *testing.T) {
+	isolateCredentials(t)
+	ca := newTestCA(t)
+	clientCA := newTestCA(t)
+	ts, clientName := newTLSWhoamiServer(t, ca, clientCA)
+	certPEM, keyPEM := clientCA.issue(t, "terraform-ci", x509.ExtKeyUsageClientAuth)
+
+	attrs := map[string]tftypes.Value{
+		"token":              tftypes.NewValue(tftypes.String, "token"),
+		"endpoint":           tftypes.NewValue(tftypes.String, ts.URL),
+		"ca_cert_pem":        tftypes.NewValue(tftypes.String, string(ca.certPEM)),
+		"verify_credentials": tftypes.NewValue(tftypes.Bool, true),
+	}
+
+	// The server rejects the handshake without a client certificate.
+	resp := configure(t, attrs)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Credentials", resp.Diagnostics.Errors()[0].Summary())
+
+	attrs["client_cert"] = tftypes.NewValue(tftypes.String, string(certPEM))
+	attrs["client_key"] = tftypes.NewValue(tftypes.String, string(keyPEM))
+	resp = configure(t, attrs)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, "terraform-ci", clientName())
+}
+
+func TestConfigure_proxyURL(t *testing.T) {
+	isolateCredentials(t)
+	ca := newTestCA(t)
+	ts, _ := newTLSWhoamiServer(t, ca, nil)
+	proxy, proxiedHosts := newConnectProxy(t)
+
+	resp := configure(t, map[string]tftypes.Value{
+		"token":              tftypes.NewValue(tftypes.String, "token"),
+		"endpoint":           tftypes.NewValue(tftypes.String, ts.URL),
+		"ca_cert_pem":        tftypes.NewValue(tftypes.String, string(ca.certPEM)),
+		"proxy_url":          tftypes.NewValue(tftypes.String, proxy.URL),
+		"verify_credentials": tftypes.NewValue(tftypes.Bool, true),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, []string{ts.Listener.Addr().String()}, proxiedHosts())
+}
+
+func TestConfigure_transportErrors(t *testing.T) {
+	isolateCredentials(t)
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, "terraform-ci", x509.ExtKeyUsageClientAuth)
+	_, otherKeyPEM := ca.issue(t, "other", x509.ExtKeyUsageClientAuth)
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	cases := []struct {
+		name     string
+		attrs    map[string]tftypes.Value
+		expected string
+	}{
+		{
+			name:     "ca_cert_file not found",
+			attrs:    map[string]tftypes.Value{"ca_cert_file": tftypes.NewValue(tftypes.String, "/nonexistent/ca.pem")},
+			expected: "Invalid CA Certificate",
+		},
+		{
+			name:     "ca_cert_file not PEM",
+			attrs:    map[string]tftypes.Value{"ca_cert_file": tftypes.NewValue(tftypes.String, notPEM)},
+			expected: "Invalid CA Certificate",
+		},
+		{
+			name:     "ca_cert_pem not PEM",
+			attrs:    map[string]tftypes.Value{"ca_cert_pem": tftypes.NewValue(tftypes.String, "not a certificate")},
+			expected: "Invalid CA Certificate",
+		},
+		{
+			name:     "client_cert without client_key",
+			attrs:    map[string]tftypes.Value{"client_cert": tftypes.NewValue(tftypes.String, string(certPEM))},
+			expected: "Missing Client Key",
+		},
+		{
+			name:     "client_key without client_cert",
+			attrs:    map[string]tftypes.Value{"client_key": tftypes.NewValue(tftypes.String, string(keyPEM))},
+			expected: "Missing Client Certificate",
+		},
+		{
+			name: "mismatched client key",
+			attrs: map[string]tftypes.Value{
+				"client_cert": tftypes.NewValue(tftypes.String, string(certPEM)),
+				"client_key":  tftypes.NewValue(tftypes.String, string(otherKeyPEM)),
+			},
+			expected: "Invalid Client Certificate",
+		},
+		{
+			name:     "proxy_url without scheme",
+			attrs:    map[string]tftypes.Value{"proxy_url": tftypes.NewValue(tftypes.String, "proxy.example.com:3128")},
+			expected: "Invalid Proxy URL",
+		},
+		{
+			name:     "proxy_url unsupported scheme",
+			attrs:    map[string]tftypes.Value{"proxy_url": tftypes.NewValue(tftypes.String, "ftp://proxy.example.com")},
+			expected: "Invalid Proxy URL",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs := map[string]tftypes.Value{
+				"token": tftypes.NewValue(tftypes.String, "token"),
+			}
+			for name, v := range tc.attrs {
+				attrs[name] = v
+			}
+
+			resp := configure(t, attrs)
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tc.expected, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
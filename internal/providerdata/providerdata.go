@@ -0,0 +1,62 @@
+// Package providerdata holds the provider configuration that resources and
+// data sources receive when they are configured.
+package providerdata
+
+import (
+	"context"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProviderData is passed to resources and data sources as their provider
+// data.
+type ProviderData struct {
+	Client *datafy.Client
+	// DefaultAccountId is used by resources whose account_id is not
+	// configured. Empty if there is no default.
+	DefaultAccountId string
+	// DefaultLabels are merged into the labels of every account.
+	DefaultLabels map[string]string
+}
+
+// PlanAccountId plans the provider's default account ID for a resource whose
+// account_id attribute is not configured, and fails the plan if there is no
+// default either. It reports whether the planned account ID differs from the
+// one in state, since attribute plan modifiers such as RequiresReplace do not
+// see values set here.
+//
+// data is nil while the provider is not configured, in which case the
+// account ID is left unknown.
+func PlanAccountId(ctx context.Context, data *ProviderData, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() || data == nil {
+		return false
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("account_id"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return false
+	}
+
+	if data.DefaultAccountId == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account_id"),
+			"Missing Account ID",
+			"account_id must be set, either on the resource or as default_account_id in the provider configuration. default_account_id can also be set through the DATAFY_ACCOUNT_ID environment variable.",
+		)
+		return false
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("account_id"), types.StringValue(data.DefaultAccountId))...)
+
+	if req.State.Raw.IsNull() {
+		return false
+	}
+	var current types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("account_id"), &current)...)
+	return current.ValueString() != data.DefaultAccountId
+}
@@ -0,0 +1,98 @@
+package providerdata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"account_id": schema.StringAttribute{Optional: true, Computed: true},
+	},
+}
+
+var (
+	null    = tftypes.NewValue(tftypes.String, nil)
+	unknown = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+)
+
+func testObject(accountId tftypes.Value) tftypes.Value {
+	return tftypes.NewValue(testSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{"account_id": accountId})
+}
+
+// planAccountId runs PlanAccountId for a resource with the given configured,
+// planned and current account ID. A nil state means the resource is created.
+func planAccountId(t *testing.T, data *ProviderData, config, plan tftypes.Value, state *tftypes.Value) (*resource.ModifyPlanResponse, bool) {
+	t.Helper()
+
+	stateRaw := tftypes.NewValue(testSchema.Type().TerraformType(context.Background()), nil)
+	if state != nil {
+		stateRaw = testObject(*state)
+	}
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: testSchema, Raw: testObject(config)},
+		Plan:   tfsdk.Plan{Schema: testSchema, Raw: testObject(plan)},
+		State:  tfsdk.State{Schema: testSchema, Raw: stateRaw},
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	changed := PlanAccountId(context.Background(), data, req, resp)
+	return resp, changed
+}
+
+func plannedAccountId(t *testing.T, resp *resource.ModifyPlanResponse) types.String {
+	t.Helper()
+
+	var accountId types.String
+	resp.Plan.GetAttribute(context.Background(), path.Root("account_id"), &accountId)
+	return accountId
+}
+
+func TestPlanAccountId_default(t *testing.T) {
+	resp, changed := planAccountId(t, &ProviderData{DefaultAccountId: "acc-default"}, null, unknown, nil)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.False(t, changed)
+	assert.Equal(t, "acc-default", plannedAccountId(t, resp).ValueString())
+}
+
+func TestPlanAccountId_configured(t *testing.T) {
+	configured := tftypes.NewValue(tftypes.String, "acc-123")
+	resp, changed := planAccountId(t, &ProviderData{DefaultAccountId: "acc-default"}, configured, configured, nil)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.False(t, changed)
+	assert.Equal(t, "acc-123", plannedAccountId(t, resp).ValueString())
+}
+
+func TestPlanAccountId_missing(t *testing.T) {
+	resp, _ := planAccountId(t, &ProviderData{}, null, unknown, nil)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Missing Account ID", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestPlanAccountId_defaultChanged(t *testing.T) {
+	current := tftypes.NewValue(tftypes.String, "acc-old")
+	resp, changed := planAccountId(t, &ProviderData{DefaultAccountId: "acc-default"}, null, current, &current)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, changed)
+	assert.Equal(t, "acc-default", plannedAccountId(t, resp).ValueString())
+}
+
+func TestPlanAccountId_notConfigured(t *testing.T) {
+	resp, changed := planAccountId(t, nil, null, unknown, nil)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.False(t, changed)
+	assert.True(t, plannedAccountId(t, resp).IsUnknown())
+}
@@ -0,0 +1,48 @@
+// Package retry polls an operation with exponential backoff until it
+// succeeds, fails permanently or the context is done.
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff describes the delay between attempts. The delay starts at Initial,
+// is multiplied by two after every attempt and is capped at Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// DefaultBackoff is suited to waiting for eventually consistent AWS and
+// Datafy state.
+var DefaultBackoff = Backoff{
+	Initial: 2 * time.Second,
+	Max:     30 * time.Second,
+}
+
+// Do calls fn until it returns nil or an error for which retryable returns
+// false. When ctx is done before that, the last error returned by fn is
+// returned.
+func Do(ctx context.Context, b Backoff, retryable func(error) bool, fn func(ctx context.Context) error) error {
+	delay := b.Initial
+	for {
+		err := fn(ctx)
+		if err == nil || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > b.Max {
+			delay = b.Max
+		}
+	}
+}
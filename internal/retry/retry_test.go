@@ -0,0 +1,59 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errPermanent = errors.New("permanent")
+	testBackoff  = Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond}
+)
+
+func isTransient(err error) bool {
+	return errors.Is(err, errTransient)
+}
+
+func TestDo_succeedsAfterRetries(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), testBackoff, isTransient, func(ctx context.Context) error {
+		attempts++
+		if attempts < 4 {
+			return errTransient
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, attempts)
+}
+
+func TestDo_permanentError(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), testBackoff, isTransient, func(ctx context.Context) error {
+		attempts++
+		return errPermanent
+	})
+
+	assert.ErrorIs(t, err, errPermanent)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDo_timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := Do(ctx, testBackoff, isTransient, func(ctx context.Context) error {
+		attempts++
+		return errTransient
+	})
+
+	assert.ErrorIs(t, err, errTransient)
+	assert.Greater(t, attempts, 1)
+}
@@ -0,0 +1,284 @@
+// Package rulelogic analyzes Datafy autoscaling rule conditions symbolically.
+//
+// It understands the subset of JsonLogic documented for autoscaling rules: an
+// optional top-level `and` of `in` conditions on single-value variables,
+// `some`/`none` conditions on array variables, and `!` negations of those.
+package rulelogic
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var singleValueVars = map[string]bool{
+	"instance_id":     true,
+	"cluster_name":    true,
+	"node_group_name": true,
+}
+
+var arrayVars = map[string]bool{
+	"tags":          true,
+	"instance_tags": true,
+}
+
+// Overlap reports whether some volume can match both rules. It returns an
+// error when either rule uses a construct that cannot be analyzed.
+func Overlap(a, b json.RawMessage) (bool, error) {
+	ca, err := parse(a)
+	if err != nil {
+		return false, err
+	}
+	cb, err := parse(b)
+	if err != nil {
+		return false, err
+	}
+
+	ca.merge(cb)
+	return ca.satisfiable(), nil
+}
+
+// valueSet constrains a single-value variable. A nil in means any value.
+type valueSet struct {
+	in    map[string]bool
+	notIn map[string]bool
+}
+
+// arraySet constrains an array variable: it must contain at least one value
+// of every some set and no value of none.
+type arraySet struct {
+	some [][]string
+	none map[string]bool
+}
+
+type constraints struct {
+	single map[string]*valueSet
+	arrays map[string]*arraySet
+}
+
+func parse(rule json.RawMessage) (*constraints, error) {
+	var v interface{}
+	if err := json.Unmarshal(rule, &v); err != nil {
+		return nil, err
+	}
+
+	c := &constraints{
+		single: map[string]*valueSet{},
+		arrays: map[string]*arraySet{},
+	}
+	if err := c.add(v, false); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *constraints) add(cond interface{}, negated bool) error {
+	op, args, err := operator(cond)
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case "and":
+		if negated {
+			return fmt.Errorf("negated %q is not supported", op)
+		}
+		for _, arg := range args {
+			if err := c.add(arg, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "!":
+		if len(args) != 1 {
+			return fmt.Errorf("%q expects one argument", op)
+		}
+		return c.add(args[0], !negated)
+	case "in":
+		name, values, err := varIn(args)
+		if err != nil {
+			return err
+		}
+		if !singleValueVars[name] {
+			return fmt.Errorf("%q cannot be used with variable %q", op, name)
+		}
+		s := c.single[name]
+		if s == nil {
+			s = &valueSet{notIn: map[string]bool{}}
+			c.single[name] = s
+		}
+		if negated {
+			for _, v := range values {
+				s.notIn[v] = true
+			}
+		} else {
+			s.in = intersect(s.in, values)
+		}
+		return nil
+	case "some", "none":
+		if len(args) != 2 {
+			return fmt.Errorf("%q expects two arguments", op)
+		}
+		name, err := varName(args[0])
+		if err != nil {
+			return err
+		}
+		if !arrayVars[name] {
+			return fmt.Errorf("%q cannot be used with variable %q", op, name)
+		}
+		innerOp, innerArgs, err := operator(args[1])
+		if err != nil {
+			return err
+		}
+		if innerOp != "in" {
+			return fmt.Errorf("%q only supports an inner %q, got %q", op, "in", innerOp)
+		}
+		elem, values, err := varIn(innerArgs)
+		if err != nil {
+			return err
+		}
+		if elem != "" {
+			return fmt.Errorf("inner %q must use the current element {\"var\": \"\"}", innerOp)
+		}
+		a := c.arrays[name]
+		if a == nil {
+			a = &arraySet{none: map[string]bool{}}
+			c.arrays[name] = a
+		}
+		if (op == "some") != negated {
+			a.some = append(a.some, values)
+		} else {
+			for _, v := range values {
+				a.none[v] = true
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("operator %q is not supported", op)
+	}
+}
+
+func (c *constraints) merge(o *constraints) {
+	for name, os := range o.single {
+		s := c.single[name]
+		if s == nil {
+			c.single[name] = os
+			continue
+		}
+		if os.in != nil {
+			s.in = intersect(s.in, keys(os.in))
+		}
+		for v := range os.notIn {
+			s.notIn[v] = true
+		}
+	}
+	for name, oa := range o.arrays {
+		a := c.arrays[name]
+		if a == nil {
+			c.arrays[name] = oa
+			continue
+		}
+		a.some = append(a.some, oa.some...)
+		for v := range oa.none {
+			a.none[v] = true
+		}
+	}
+}
+
+func (c *constraints) satisfiable() bool {
+	for _, s := range c.single {
+		if s.in == nil {
+			// Variables have an unbounded domain, so excluding a finite set
+			// of values always leaves a match.
+			continue
+		}
+		ok := false
+		for v := range s.in {
+			if !s.notIn[v] {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	for _, a := range c.arrays {
+		for _, some := range a.some {
+			ok := false
+			for _, v := range some {
+				if !a.none[v] {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func operator(cond interface{}) (string, []interface{}, error) {
+	m, ok := cond.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return "", nil, fmt.Errorf("expected an object with a single operator, got %v", cond)
+	}
+	for op, arg := range m {
+		if args, ok := arg.([]interface{}); ok {
+			return op, args, nil
+		}
+		return op, []interface{}{arg}, nil
+	}
+	return "", nil, nil
+}
+
+func varName(v interface{}) (string, error) {
+	op, args, err := operator(v)
+	if err != nil || op != "var" || len(args) != 1 {
+		return "", fmt.Errorf("expected {\"var\": <name>}, got %v", v)
+	}
+	name, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("expected {\"var\": <name>}, got %v", v)
+	}
+	return name, nil
+}
+
+func varIn(args []interface{}) (string, []string, error) {
+	if len(args) != 2 {
+		return "", nil, fmt.Errorf("%q expects two arguments", "in")
+	}
+	name, err := varName(args[0])
+	if err != nil {
+		return "", nil, err
+	}
+	list, ok := args[1].([]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("%q expects a list of values, got %v", "in", args[1])
+	}
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		values = append(values, fmt.Sprint(v))
+	}
+	return name, values, nil
+}
+
+func intersect(set map[string]bool, values []string) map[string]bool {
+	res := make(map[string]bool, len(values))
+	for _, v := range values {
+		if set == nil || set[v] {
+			res[v] = true
+		}
+	}
+	return res
+}
+
+func keys(set map[string]bool) []string {
+	res := make([]string, 0, len(set))
+	for v := range set {
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,98 @@
+package rulelogic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlap(t *testing.T) {
+	cases := []struct {
+		name    string
+		a, b    string
+		overlap bool
+	}{
+		{
+			name:    "same cluster",
+			a:       `{"in":[{"var":"cluster_name"},["prod"]]}`,
+			b:       `{"and":[{"in":[{"var":"cluster_name"},["prod","stg"]]}]}`,
+			overlap: true,
+		},
+		{
+			name:    "disjoint clusters",
+			a:       `{"in":[{"var":"cluster_name"},["prod"]]}`,
+			b:       `{"in":[{"var":"cluster_name"},["stg"]]}`,
+			overlap: false,
+		},
+		{
+			name:    "different variables",
+			a:       `{"in":[{"var":"cluster_name"},["prod"]]}`,
+			b:       `{"in":[{"var":"node_group_name"},["workers"]]}`,
+			overlap: true,
+		},
+		{
+			name:    "negated cluster",
+			a:       `{"in":[{"var":"cluster_name"},["prod"]]}`,
+			b:       `{"!":{"in":[{"var":"cluster_name"},["prod"]]}}`,
+			overlap: false,
+		},
+		{
+			name:    "negated other cluster",
+			a:       `{"in":[{"var":"cluster_name"},["prod","stg"]]}`,
+			b:       `{"!":[{"in":[{"var":"cluster_name"},["prod"]]}]}`,
+			overlap: true,
+		},
+		{
+			name:    "tags some and some",
+			a:       `{"some":[{"var":"tags"},{"in":[{"var":""},["env:prod"]]}]}`,
+			b:       `{"some":[{"var":"tags"},{"in":[{"var":""},["team:infra"]]}]}`,
+			overlap: true,
+		},
+		{
+			name:    "tags some and none",
+			a:       `{"some":[{"var":"tags"},{"in":[{"var":""},["env:prod"]]}]}`,
+			b:       `{"none":[{"var":"tags"},{"in":[{"var":""},["env:prod","env:stg"]]}]}`,
+			overlap: false,
+		},
+		{
+			name:    "tags negated none",
+			a:       `{"some":[{"var":"tags"},{"in":[{"var":""},["env:prod"]]}]}`,
+			b:       `{"!":{"none":[{"var":"tags"},{"in":[{"var":""},["env:prod"]]}]}}`,
+			overlap: true,
+		},
+		{
+			name:    "combined conditions",
+			a:       `{"and":[{"in":[{"var":"cluster_name"},["prod"]]},{"some":[{"var":"instance_tags"},{"in":[{"var":""},["role:db"]]}]}]}`,
+			b:       `{"and":[{"in":[{"var":"cluster_name"},["prod"]]},{"none":[{"var":"instance_tags"},{"in":[{"var":""},["role:db"]]}]}]}`,
+			overlap: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Overlap(json.RawMessage(tc.a), json.RawMessage(tc.b))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.overlap, got)
+
+			got, err = Overlap(json.RawMessage(tc.b), json.RawMessage(tc.a))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.overlap, got)
+		})
+	}
+}
+
+func TestOverlap_unsupported(t *testing.T) {
+	unsupported := []string{
+		`{"or":[{"in":[{"var":"cluster_name"},["prod"]]}]}`,
+		`{"!":{"and":[{"in":[{"var":"cluster_name"},["prod"]]}]}}`,
+		`{"in":[{"var":"tags"},["env:prod"]]}`,
+		`{"some":[{"var":"cluster_name"},{"in":[{"var":""},["prod"]]}]}`,
+		`{"in":[{"var":"unknown"},["x"]]}`,
+		`not json`,
+	}
+	for _, rule := range unsupported {
+		_, err := Overlap(json.RawMessage(rule), json.RawMessage(`{"in":[{"var":"cluster_name"},["prod"]]}`))
+		assert.Error(t, err, rule)
+	}
+}
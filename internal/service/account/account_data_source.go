@@ -5,13 +5,18 @@ import (
 	"fmt"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSourceWithConfigure = &DataSource{}
+var (
+	_ datasource.DataSourceWithConfigure      = &DataSource{}
+	_ datasource.DataSourceWithValidateConfig = &DataSource{}
+)
 
 func NewDataSource() datasource.DataSource {
 	return &DataSource{}
@@ -24,7 +29,11 @@ type DataSource struct {
 type DataSourceModel struct {
 	Name            types.String `tfsdk:"name"`
 	Id              types.String `tfsdk:"id"`
+	LabelFilter     types.Map    `tfsdk:"label_filter"`
 	ParentAccountId types.String `tfsdk:"parent_account_id"`
+	ExternalId      types.String `tfsdk:"external_id"`
+	Description     types.String `tfsdk:"description"`
+	Labels          types.Map    `tfsdk:"labels"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -33,11 +42,17 @@ func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataReques
 
 func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Retrieves a specific Datafy account.",
+		Description: "Retrieves a specific Datafy account, by ID or by its labels.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "The unique identifier of the Datafy account to look up.",
-				Required:    true,
+				Description: "The unique identifier of the Datafy account to look up. At least one of `id` or `label_filter` must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"label_filter": schema.MapAttribute{
+				Description: "Labels the account must have. Without `id`, exactly one account the provider's token can access must have all of these labels.",
+				ElementType: types.StringType,
+				Optional:    true,
 			},
 			"name": schema.StringAttribute{
 				Description: "The display name of the account.",
@@ -47,6 +62,19 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Description: "The unique identifier of the parent Datafy account.",
 				Computed:    true,
 			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID Datafy passes as `sts:ExternalId` when assuming the account's IAM role. Use it in the role's trust policy condition.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the account.",
+				Computed:    true,
+			},
+			"labels": schema.MapAttribute{
+				Description: "All labels of the account.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -57,17 +85,34 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*datafy.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *datafy.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
+}
+
+func (d *DataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config DataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Id.IsNull() && config.LabelFilter.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Missing Attribute Configuration",
+			"At least one of id or label_filter must be set.",
+		)
+	}
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -78,18 +123,67 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
-	gcr, err := d.client.GetAccount(ctx, &datafy.GetAccountRequest{
-		AccountId: plan.Id.ValueString(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error read account",
-			"Could not read account: "+err.Error(),
-		)
+	labelFilter, diags := expandLabels(ctx, plan.LabelFilter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan.Name = types.StringValue(gcr.Account.AccountName)
-	plan.ParentAccountId = types.StringValue(gcr.Account.ParentAccountId)
+	var account datafy.Account
+	if !plan.Id.IsNull() {
+		gcr, err := d.client.GetAccount(ctx, &datafy.GetAccountRequest{
+			AccountId: plan.Id.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error read account",
+				"Could not read account: "+err.Error(),
+			)
+			return
+		}
+		if !gcr.Account.HasLabels(labelFilter) {
+			resp.Diagnostics.AddError(
+				"No Matching Account",
+				fmt.Sprintf("Account %s does not have all of the labels in label_filter.", plan.Id.ValueString()),
+			)
+			return
+		}
+		account = gcr.Account
+	} else {
+		lar, err := d.client.ListAccounts(ctx, &datafy.ListAccountsRequest{
+			Labels: labelFilter,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error read account",
+				"Could not list accounts: "+err.Error(),
+			)
+			return
+		}
+		switch len(lar.Accounts) {
+		case 0:
+			resp.Diagnostics.AddError(
+				"No Matching Account",
+				"No account has all of the labels in label_filter.",
+			)
+			return
+		case 1:
+			account = lar.Accounts[0]
+		default:
+			resp.Diagnostics.AddError(
+				"Multiple Matching Accounts",
+				fmt.Sprintf("%d accounts have all of the labels in label_filter. Add labels to the filter or look the account up by id.", len(lar.Accounts)),
+			)
+			return
+		}
+	}
+
+	plan.Id = types.StringValue(account.AccountId)
+	plan.Name = types.StringValue(account.AccountName)
+	plan.ParentAccountId = types.StringValue(account.ParentAccountId)
+	plan.ExternalId = types.StringValue(account.ExternalId)
+	plan.Description = types.StringValue(account.Description)
+	plan.Labels, diags = flattenLabels(ctx, account.Labels)
+	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
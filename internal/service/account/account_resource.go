@@ -5,10 +5,15 @@ import (
 	"fmt"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -17,6 +22,7 @@ import (
 var (
 	_ resource.ResourceWithConfigure   = &Resource{}
 	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -24,13 +30,21 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client *datafy.Client
+	client       *datafy.Client
+	providerData *providerdata.ProviderData
 }
 
 type ResourceModel struct {
-	Name            types.String `tfsdk:"name"`
-	Id              types.String `tfsdk:"id"`
-	ParentAccountId types.String `tfsdk:"parent_account_id"`
+	Name               types.String   `tfsdk:"name"`
+	Id                 types.String   `tfsdk:"id"`
+	ParentAccountId    types.String   `tfsdk:"parent_account_id"`
+	ExternalId         types.String   `tfsdk:"external_id"`
+	Description        types.String   `tfsdk:"description"`
+	Labels             types.Map      `tfsdk:"labels"`
+	LabelsAll          types.Map      `tfsdk:"labels_all"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool     `tfsdk:"force_destroy"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,12 +67,59 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				},
 			},
 			"parent_account_id": schema.StringAttribute{
-				Description: "The unique identifier of the parent Datafy account. This is your organization's root account.",
+				Description: "The unique identifier of the parent Datafy account. Must be an account the provider's token can access. Defaults to the account of the provider's token, usually your organization's root account. Changing this forces a new account to be created.",
+				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"description": schema.StringAttribute{
+				Description: "A description of the account.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"labels": schema.MapAttribute{
+				Description: "Key/value labels of the account, e.g. cost center, owning team or environment. Labels override the provider's `default_labels` with the same key.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"labels_all": schema.MapAttribute{
+				Description: "All labels of the account, including those inherited from the provider's `default_labels`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether the account is protected from deletion. While set to `true`, destroying the account fails. Set it to `false` and apply before destroying the account. Defaults to `true` for new accounts. Accounts created with an earlier provider version or imported keep the protection they have.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the account also deletes its autoscaling rules, AWS account associations and role ARN, and revokes its tokens. When `false`, destroying an account that still has any of them fails and lists them. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID Datafy passes as `sts:ExternalId` when assuming the account's IAM role. Use it in the role's trust policy condition.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Delete:            true,
+				CreateDescription: "How long to wait for a new account to become active, e.g. `20m`. Defaults to `10m`.",
+				DeleteDescription: "How long to wait for a deleted account to be fully removed, e.g. `20m`. Defaults to `10m`.",
+			}),
 		},
 	}
 }
@@ -69,17 +130,18 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*datafy.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *datafy.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.providerData = data
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -90,8 +152,28 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labels, diags := expandLabels(ctx, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	labelsAll := mergeLabels(r.providerData.DefaultLabels, labels)
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	car, err := r.client.CreateAccount(ctx, &datafy.CreateAccountRequest{
-		AccountName: plan.Name.ValueString(),
+		AccountName:        plan.Name.ValueString(),
+		ParentAccountId:    plan.ParentAccountId.ValueString(),
+		Description:        plan.Description.ValueString(),
+		Labels:             labelsAll,
+		DeletionProtection: plan.DeletionProtection.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -103,6 +185,22 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	plan.Id = types.StringValue(car.Account.AccountId)
 	plan.ParentAccountId = types.StringValue(car.Account.ParentAccountId)
+	plan.ExternalId = types.StringValue(car.Account.ExternalId)
+	plan.LabelsAll, diags = flattenLabels(ctx, labelsAll)
+	resp.Diagnostics.Append(diags...)
+
+	// Accounts are provisioned asynchronously. The state is saved even if
+	// the wait fails so that Terraform taints the account rather than
+	// losing track of it.
+	account, err := waitAccountReady(ctx, r.client, car.Account.AccountId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating account",
+			"Could not wait for account to become active: "+waitErrorDetail(err, createTimeout),
+		)
+	} else if account.ExternalId != "" {
+		plan.ExternalId = types.StringValue(account.ExternalId)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -127,10 +225,112 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	state.Name = types.StringValue(gcr.Account.AccountName)
 	state.ParentAccountId = types.StringValue(gcr.Account.ParentAccountId)
+	state.ExternalId = types.StringValue(gcr.Account.ExternalId)
+	state.Description = types.StringValue(gcr.Account.Description)
+
+	configured, diags := expandLabels(ctx, state.Labels)
+	resp.Diagnostics.Append(diags...)
+	labels := resourceLabels(gcr.Account.Labels, r.providerData.DefaultLabels, configured)
+	if len(labels) > 0 || !state.Labels.IsNull() {
+		state.Labels, diags = flattenLabels(ctx, labels)
+		resp.Diagnostics.Append(diags...)
+	}
+	state.LabelsAll, diags = flattenLabels(ctx, gcr.Account.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// When the API does not report deletion protection it is enforced by the
+	// provider alone, so the state value is kept. Accounts imported or created
+	// with an earlier provider version were never protected and stay that way
+	// until deletion_protection is set.
+	if gcr.Account.DeletionProtection != nil {
+		state.DeletionProtection = types.BoolValue(*gcr.Account.DeletionProtection)
+	} else if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+	// force_destroy only exists in Terraform.
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// The state is null on create.
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only new accounts default to deletion protection. Existing accounts
+	// keep the value in state, which UseStateForUnknown carries over.
+	if req.State.Raw.IsNull() && plan.DeletionProtection.IsUnknown() {
+		var configured types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &configured)...)
+		if configured.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The provider is not configured yet, e.g. during validation with
+	// unknown provider configuration.
+	if r.client == nil {
+		return
+	}
+
+	// labels_all is known at plan time unless labels are not.
+	if !plan.Labels.IsUnknown() {
+		labels, diags := expandLabels(ctx, plan.Labels)
+		resp.Diagnostics.Append(diags...)
+		labelsAll, diags := flattenLabels(ctx, mergeLabels(r.providerData.DefaultLabels, labels))
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Only a parent that is set in the configuration and differs from the
+	// current one needs to be checked.
+	if plan.ParentAccountId.IsNull() || plan.ParentAccountId.IsUnknown() || plan.ParentAccountId.Equal(state.ParentAccountId) {
+		return
+	}
+
+	parentAccountId := plan.ParentAccountId.ValueString()
+	if parentAccountId == state.Id.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent_account_id"),
+			"Invalid Parent Account",
+			"An account cannot be its own parent.",
+		)
+		return
+	}
+
+	if _, err := r.client.GetAccount(ctx, &datafy.GetAccountRequest{AccountId: parentAccountId}); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parent_account_id"),
+			"Invalid Parent Account",
+			fmt.Sprintf("The parent account %s is not reachable with the provider's token: %s", parentAccountId, err.Error()),
+		)
+	}
+}
+
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan ResourceModel
 
@@ -139,9 +339,19 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	labels, diags := expandLabels(ctx, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	labelsAll := mergeLabels(r.providerData.DefaultLabels, labels)
+
 	_, err := r.client.UpdateAccount(ctx, &datafy.UpdateAccountRequest{
-		AccountId:   plan.Id.ValueString(),
-		AccountName: plan.Name.ValueString(),
+		AccountId:          plan.Id.ValueString(),
+		AccountName:        plan.Name.ValueString(),
+		Description:        plan.Description.ValueString(),
+		Labels:             labelsAll,
+		DeletionProtection: plan.DeletionProtection.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -151,6 +361,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	plan.LabelsAll, diags = flattenLabels(ctx, labelsAll)
+	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -166,9 +378,55 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
-	_, err := r.client.DeleteAccount(ctx, &datafy.DeleteAccountRequest{
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Account Deletion Protected",
+			fmt.Sprintf("Cannot delete account %s (%s) because deletion_protection is enabled. Set deletion_protection = false and apply before destroying the account.", state.Name.ValueString(), state.Id.ValueString()),
+		)
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	children, err := listAccountChildren(ctx, r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error delete account",
+			"Could not list account resources: "+err.Error(),
+		)
+		return
+	}
+	if !children.empty() {
+		if !state.ForceDestroy.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Account Not Empty",
+				fmt.Sprintf("Cannot delete account %s (%s) because it still has:\n%s\n\nDelete them first, or set force_destroy = true and apply to delete them with the account.", state.Name.ValueString(), state.Id.ValueString(), children),
+			)
+			return
+		}
+
+		if err := deleteAccountChildren(ctx, r.client, state.Id.ValueString(), children); err != nil {
+			resp.Diagnostics.AddError(
+				"Error delete account",
+				"Could not delete account resources: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	_, err = r.client.DeleteAccount(ctx, &datafy.DeleteAccountRequest{
 		AccountId: state.Id.ValueString(),
 	})
+	if datafy.IsNotFound(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error delete account",
@@ -176,4 +434,13 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		)
 		return
 	}
+
+	// The account is removed asynchronously. Waiting for it to be gone lets
+	// an account with the same name, or its parent, be managed right after.
+	if err := waitAccountDeleted(ctx, r.client, state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error delete account",
+			"Could not wait for account to be deleted: "+waitErrorDetail(err, deleteTimeout),
+		)
+	}
 }
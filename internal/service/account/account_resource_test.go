@@ -0,0 +1,54 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyPlan_deletionProtectionDefault(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&Resource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	s := schemaResp.Schema
+
+	newPlan := func(deletionProtection types.Bool) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		require.False(t, plan.SetAttribute(ctx, path.Root("name"), "test").HasError())
+		require.False(t, plan.SetAttribute(ctx, path.Root("deletion_protection"), deletionProtection).HasError())
+		return plan
+	}
+	modifyPlan := func(config, plan tfsdk.Plan, state tfsdk.State) types.Bool {
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: s, Raw: config.Raw},
+			Plan:   plan,
+			State:  state,
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		(&Resource{}).ModifyPlan(ctx, req, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var deletionProtection types.Bool
+		require.False(t, resp.Plan.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection).HasError())
+		return deletionProtection
+	}
+	nullState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+
+	// New accounts are protected unless configured otherwise.
+	assert.Equal(t, types.BoolValue(true), modifyPlan(newPlan(types.BoolNull()), newPlan(types.BoolUnknown()), nullState))
+	assert.Equal(t, types.BoolValue(false), modifyPlan(newPlan(types.BoolValue(false)), newPlan(types.BoolValue(false)), nullState))
+
+	// An existing account keeps the value UseStateForUnknown planned from
+	// state, e.g. one read as unprotected after an upgrade.
+	state := tfsdk.State{Schema: s, Raw: newPlan(types.BoolValue(false)).Raw}
+	assert.Equal(t, types.BoolValue(false), modifyPlan(newPlan(types.BoolNull()), newPlan(types.BoolValue(false)), state))
+}
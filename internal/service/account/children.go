@@ -0,0 +1,110 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+)
+
+// accountChildren are the objects that belong to an account and block or are
+// orphaned by its deletion.
+type accountChildren struct {
+	AutoscalingRules       []datafy.AutoscalingRule
+	AwsAccountAssociations []datafy.AwsAccountAssociation
+	// RoleArn is empty if the account has no role ARN.
+	RoleArn string
+	Tokens  []datafy.AccountToken
+}
+
+func listAccountChildren(ctx context.Context, client *datafy.Client, accountId string) (*accountChildren, error) {
+	var children accountChildren
+
+	laarr, err := client.ListAccountAutoscalingRules(ctx, &datafy.ListAccountAutoscalingRulesRequest{AccountId: accountId})
+	if err != nil {
+		return nil, fmt.Errorf("listing autoscaling rules: %w", err)
+	}
+	children.AutoscalingRules = laarr.AutoscalingRules
+
+	laaar, err := client.ListAwsAccountAssociations(ctx, &datafy.ListAwsAccountAssociationsRequest{AccountId: accountId})
+	if err != nil && !datafy.IsNotFound(err) {
+		return nil, fmt.Errorf("listing AWS account associations: %w", err)
+	}
+	if laaar != nil {
+		children.AwsAccountAssociations = laaar.AwsAccountAssociations
+	}
+
+	garar, err := client.GetAccountRoleArn(ctx, &datafy.GetAccountRoleArnRequest{AccountId: accountId})
+	if err != nil && !datafy.IsNotFound(err) {
+		return nil, fmt.Errorf("reading role ARN: %w", err)
+	}
+	if garar != nil {
+		children.RoleArn = garar.AccountRoleArn.RoleArn
+	}
+
+	latr, err := client.ListAccountTokens(ctx, &datafy.ListAccountTokensRequest{AccountId: accountId})
+	if err != nil {
+		return nil, fmt.Errorf("listing tokens: %w", err)
+	}
+	children.Tokens = latr.AccountTokens
+
+	return &children, nil
+}
+
+func (c *accountChildren) empty() bool {
+	return len(c.AutoscalingRules) == 0 && len(c.AwsAccountAssociations) == 0 && c.RoleArn == "" && len(c.Tokens) == 0
+}
+
+// String lists the children, one per line.
+func (c *accountChildren) String() string {
+	var lines []string
+	for _, rule := range c.AutoscalingRules {
+		lines = append(lines, fmt.Sprintf("  - autoscaling rule %s (%s)", rule.RuleId, rule.Name))
+	}
+	for _, association := range c.AwsAccountAssociations {
+		lines = append(lines, fmt.Sprintf("  - AWS account association %s (%s)", association.AwsAccountId, association.RoleArn))
+	}
+	if c.RoleArn != "" {
+		lines = append(lines, fmt.Sprintf("  - role ARN %s", c.RoleArn))
+	}
+	for _, token := range c.Tokens {
+		lines = append(lines, fmt.Sprintf("  - token %s (%s)", token.TokenId, token.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// deleteAccountChildren deletes the children in dependency order: rules act
+// through the account's IAM roles, so they go first, and tokens are revoked
+// last.
+func deleteAccountChildren(ctx context.Context, client *datafy.Client, accountId string, children *accountChildren) error {
+	for _, rule := range children.AutoscalingRules {
+		_, err := client.DeleteAccountAutoscalingRule(ctx, &datafy.DeleteAccountAutoscalingRuleRequest{AccountId: accountId, RuleId: rule.RuleId})
+		if err != nil && !datafy.IsNotFound(err) {
+			return fmt.Errorf("deleting autoscaling rule %s: %w", rule.RuleId, err)
+		}
+	}
+
+	for _, association := range children.AwsAccountAssociations {
+		_, err := client.DeleteAwsAccountAssociation(ctx, &datafy.DeleteAwsAccountAssociationRequest{AccountId: accountId, AwsAccountId: association.AwsAccountId})
+		if err != nil && !datafy.IsNotFound(err) {
+			return fmt.Errorf("deleting AWS account association %s: %w", association.AwsAccountId, err)
+		}
+	}
+
+	if children.RoleArn != "" {
+		_, err := client.DeleteAccountRoleArn(ctx, &datafy.DeleteAccountRoleArnRequest{AccountId: accountId})
+		if err != nil && !datafy.IsNotFound(err) {
+			return fmt.Errorf("deleting role ARN: %w", err)
+		}
+	}
+
+	for _, token := range children.Tokens {
+		_, err := client.DeleteAccountToken(ctx, &datafy.DeleteAccountTokenRequest{AccountId: accountId, TokenId: token.TokenId})
+		if err != nil && !datafy.IsNotFound(err) {
+			return fmt.Errorf("revoking token %s: %w", token.TokenId, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/stretchr/testify/assert"
+)
+
+// newChildrenServer serves the children of acc-123 and records the requests
+// that delete them.
+func newChildrenServer(t *testing.T, withChildren bool) (*httptest.Server, *[]string) {
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var body interface{}
+		switch r.URL.Path {
+		case "/api/v1/accounts/acc-123/autoscaling/rules":
+			body = []datafy.AutoscalingRule{}
+			if withChildren {
+				body = []datafy.AutoscalingRule{{RuleId: "rule-1", Name: "grow"}}
+			}
+		case "/api/v1/accounts/acc-123/aws-accounts":
+			body = []datafy.AwsAccountAssociation{}
+			if withChildren {
+				body = []datafy.AwsAccountAssociation{{AwsAccountId: "210987654321", RoleArn: "arn:aws:iam::210987654321:role/datafy"}}
+			}
+		case "/api/v1/accounts/acc-123/role-arn":
+			if !withChildren {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			body = datafy.AccountRoleArn{RoleArn: "arn:aws:iam::123456789012:role/datafy"}
+		case "/api/v1/accounts/acc-123/tokens":
+			body = []datafy.AccountToken{}
+			if withChildren {
+				body = []datafy.AccountToken{{TokenId: "tok-1", Description: "ci"}}
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &deleted
+}
+
+func TestListAccountChildren(t *testing.T) {
+	ts, _ := newChildrenServer(t, true)
+
+	children, err := listAccountChildren(context.Background(), datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.NoError(t, err)
+	assert.False(t, children.empty())
+	assert.Equal(t, `  - autoscaling rule rule-1 (grow)
+  - AWS account association 210987654321 (arn:aws:iam::210987654321:role/datafy)
+  - role ARN arn:aws:iam::123456789012:role/datafy
+  - token tok-1 (ci)`, children.String())
+}
+
+func TestListAccountChildren_empty(t *testing.T) {
+	ts, _ := newChildrenServer(t, false)
+
+	children, err := listAccountChildren(context.Background(), datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.NoError(t, err)
+	assert.True(t, children.empty())
+}
+
+func TestDeleteAccountChildren(t *testing.T) {
+	ts, deleted := newChildrenServer(t, true)
+	client := datafy.NewClient("dummy", ts.URL)
+
+	children, err := listAccountChildren(context.Background(), client, "acc-123")
+	assert.NoError(t, err)
+
+	err = deleteAccountChildren(context.Background(), client, "acc-123", children)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/api/v1/accounts/acc-123/autoscaling/rules/rule-1",
+		"/api/v1/accounts/acc-123/aws-accounts/210987654321",
+		"/api/v1/accounts/acc-123/role-arn",
+		"/api/v1/accounts/acc-123/tokens/tok-1",
+	}, *deleted)
+}
@@ -0,0 +1,54 @@
+package account
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mergeLabels returns the provider's default labels overridden by the
+// resource's labels.
+func mergeLabels(defaults, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
+
+// resourceLabels returns the labels of an account that belong in the
+// resource's labels attribute: all labels, except those that only come from
+// the provider's default labels. Configured labels are always kept, so that
+// overriding a default label with the same value does not cause a diff.
+func resourceLabels(all, defaults, configured map[string]string) map[string]string {
+	labels := make(map[string]string, len(all))
+	for k, v := range all {
+		if _, ok := configured[k]; !ok {
+			if dv, ok := defaults[k]; ok && dv == v {
+				continue
+			}
+		}
+		labels[k] = v
+	}
+	return labels
+}
+
+func expandLabels(ctx context.Context, m types.Map) (map[string]string, diag.Diagnostics) {
+	labels := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return labels, nil
+	}
+	diags := m.ElementsAs(ctx, &labels, false)
+	return labels, diags
+}
+
+func flattenLabels(ctx context.Context, labels map[string]string) (types.Map, diag.Diagnostics) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return types.MapValueFrom(ctx, types.StringType, labels)
+}
@@ -0,0 +1,30 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLabels(t *testing.T) {
+	merged := mergeLabels(
+		map[string]string{"cost_center": "1234", "env": "prod"},
+		map[string]string{"env": "dev", "team": "checkout"},
+	)
+
+	assert.Equal(t, map[string]string{"cost_center": "1234", "env": "dev", "team": "checkout"}, merged)
+}
+
+func TestResourceLabels(t *testing.T) {
+	defaults := map[string]string{"cost_center": "1234", "env": "prod", "owner": "platform"}
+
+	labels := resourceLabels(
+		map[string]string{"cost_center": "1234", "env": "dev", "owner": "platform", "team": "checkout"},
+		defaults,
+		map[string]string{"owner": "platform"},
+	)
+
+	// cost_center only comes from the defaults, env was changed outside of
+	// Terraform and owner is configured with the default value.
+	assert.Equal(t, map[string]string{"env": "dev", "owner": "platform", "team": "checkout"}, labels)
+}
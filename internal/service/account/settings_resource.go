@@ -0,0 +1,359 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.ResourceWithConfigure      = &SettingsResource{}
+	_ resource.ResourceWithImportState    = &SettingsResource{}
+	_ resource.ResourceWithModifyPlan     = &SettingsResource{}
+	_ resource.ResourceWithValidateConfig = &SettingsResource{}
+)
+
+func NewSettingsResource() resource.Resource {
+	return &SettingsResource{}
+}
+
+type SettingsResource struct {
+	client       *datafy.Client
+	providerData *providerdata.ProviderData
+}
+
+type SettingsResourceModel struct {
+	AccountId              types.String `tfsdk:"account_id"`
+	AutoscalingEnabled     types.Bool   `tfsdk:"autoscaling_enabled"`
+	ShrinkEnabled          types.Bool   `tfsdk:"shrink_enabled"`
+	GrowThresholdPercent   types.Int64  `tfsdk:"grow_threshold_percent"`
+	ShrinkThresholdPercent types.Int64  `tfsdk:"shrink_threshold_percent"`
+	NotificationEmail      types.String `tfsdk:"notification_email"`
+}
+
+// expand overlays the configured settings on the current ones, so that
+// settings left out of the configuration keep their value.
+func (m *SettingsResourceModel) expand(current datafy.AccountSettings) datafy.AccountSettings {
+	settings := current
+	if !m.AutoscalingEnabled.IsNull() && !m.AutoscalingEnabled.IsUnknown() {
+		settings.AutoscalingEnabled = m.AutoscalingEnabled.ValueBool()
+	}
+	if !m.ShrinkEnabled.IsNull() && !m.ShrinkEnabled.IsUnknown() {
+		settings.ShrinkEnabled = m.ShrinkEnabled.ValueBool()
+	}
+	if !m.GrowThresholdPercent.IsNull() && !m.GrowThresholdPercent.IsUnknown() {
+		settings.GrowThresholdPercent = m.GrowThresholdPercent.ValueInt64()
+	}
+	if !m.ShrinkThresholdPercent.IsNull() && !m.ShrinkThresholdPercent.IsUnknown() {
+		settings.ShrinkThresholdPercent = m.ShrinkThresholdPercent.ValueInt64()
+	}
+	if !m.NotificationEmail.IsNull() && !m.NotificationEmail.IsUnknown() {
+		settings.NotificationEmail = m.NotificationEmail.ValueString()
+	}
+	return settings
+}
+
+func (m *SettingsResourceModel) flatten(settings datafy.AccountSettings) {
+	m.AutoscalingEnabled = types.BoolValue(settings.AutoscalingEnabled)
+	m.ShrinkEnabled = types.BoolValue(settings.ShrinkEnabled)
+	m.GrowThresholdPercent = types.Int64Value(settings.GrowThresholdPercent)
+	m.ShrinkThresholdPercent = types.Int64Value(settings.ShrinkThresholdPercent)
+	m.NotificationEmail = types.StringValue(settings.NotificationEmail)
+}
+
+func (r *SettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_settings"
+}
+
+func (r *SettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the volume lifecycle defaults of a Datafy account: feature toggles, default scaling thresholds and the notification email. Every account has exactly one set of settings. Settings left out of the configuration keep their current value, and destroying the resource resets all settings to the service defaults.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account whose settings to manage. Changing this resets the settings of the previous account. Defaults to the provider's `default_account_id`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"autoscaling_enabled": schema.BoolAttribute{
+				Description: "Whether Datafy autoscales the account's volumes.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"shrink_enabled": schema.BoolAttribute{
+				Description: "Whether autoscaling may reduce volume size, rather than only grow it.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"grow_threshold_percent": schema.Int64Attribute{
+				Description: "The disk utilization, in percent, above which a volume is grown unless an autoscaling rule says otherwise. Must be between 1 and 99.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"shrink_threshold_percent": schema.Int64Attribute{
+				Description: "The disk utilization, in percent, below which a volume is shrunk unless an autoscaling rule says otherwise. Must be between 1 and 99, and lower than `grow_threshold_percent`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"notification_email": schema.StringAttribute{
+				Description: "The email address that receives notifications about scaling events. Set to an empty string to disable email notifications.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+func (r *SettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config SettingsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	thresholds := []struct {
+		attr  string
+		value types.Int64
+	}{
+		{"grow_threshold_percent", config.GrowThresholdPercent},
+		{"shrink_threshold_percent", config.ShrinkThresholdPercent},
+	}
+	for _, t := range thresholds {
+		if !t.value.IsNull() && !t.value.IsUnknown() && (t.value.ValueInt64() < 1 || t.value.ValueInt64() > 99) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(t.attr),
+				"Invalid Threshold",
+				fmt.Sprintf("%s must be between 1 and 99, got: %d.", t.attr, t.value.ValueInt64()),
+			)
+		}
+	}
+
+	grow, shrink := config.GrowThresholdPercent, config.ShrinkThresholdPercent
+	if !grow.IsNull() && !grow.IsUnknown() && !shrink.IsNull() && !shrink.IsUnknown() && shrink.ValueInt64() >= grow.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("shrink_threshold_percent"),
+			"Invalid Threshold",
+			fmt.Sprintf("shrink_threshold_percent (%d) must be lower than grow_threshold_percent (%d).", shrink.ValueInt64(), grow.ValueInt64()),
+		)
+	}
+
+	email := config.NotificationEmail
+	if !email.IsNull() && !email.IsUnknown() && email.ValueString() != "" {
+		if _, err := mail.ParseAddress(email.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("notification_email"),
+				"Invalid Email Address",
+				fmt.Sprintf("notification_email must be an email address: %s", err.Error()),
+			)
+		}
+	}
+}
+
+func (r *SettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.putSettings(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating account settings",
+			"Could not create account settings: "+err.Error(),
+		)
+		return
+	}
+
+	plan.flatten(*settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gasr, err := r.client.GetAccountSettings(ctx, &datafy.GetAccountSettingsRequest{
+		AccountId: state.AccountId.ValueString(),
+	})
+	// The settings are gone with their account.
+	if datafy.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error read account settings",
+			"Could not read account settings: "+err.Error(),
+		)
+		return
+	}
+
+	state.flatten(gasr.AccountSettings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *SettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if providerdata.PlanAccountId(ctx, r.providerData, req, resp) {
+		resp.RequiresReplace.Append(path.Root("account_id"))
+	}
+
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan SettingsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ValidateConfig checks the thresholds when both are configured. When
+	// only one is, the other is planned from state, so the pair can be
+	// checked here unless the settings are new.
+	if config.GrowThresholdPercent.IsNull() == config.ShrinkThresholdPercent.IsNull() {
+		return
+	}
+	grow, shrink := plan.GrowThresholdPercent, plan.ShrinkThresholdPercent
+	if grow.IsUnknown() || shrink.IsUnknown() || grow.IsNull() || shrink.IsNull() || shrink.ValueInt64() < grow.ValueInt64() {
+		return
+	}
+
+	attr := "shrink_threshold_percent"
+	if config.ShrinkThresholdPercent.IsNull() {
+		attr = "grow_threshold_percent"
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root(attr),
+		"Invalid Threshold",
+		fmt.Sprintf("shrink_threshold_percent (%d) must be lower than grow_threshold_percent (%d).", shrink.ValueInt64(), grow.ValueInt64()),
+	)
+}
+
+func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.putSettings(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error update account settings",
+			"Could not update account settings: "+err.Error(),
+		)
+		return
+	}
+
+	plan.flatten(*settings)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// putSettings replaces the account's settings with the planned ones. The API
+// only replaces settings as a whole, so the current settings are read first.
+func (r *SettingsResource) putSettings(ctx context.Context, plan *SettingsResourceModel) (*datafy.AccountSettings, error) {
+	gasr, err := r.client.GetAccountSettings(ctx, &datafy.GetAccountSettingsRequest{
+		AccountId: plan.AccountId.ValueString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pasr, err := r.client.PutAccountSettings(ctx, &datafy.PutAccountSettingsRequest{
+		AccountId:       plan.AccountId.ValueString(),
+		AccountSettings: plan.expand(gasr.AccountSettings),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pasr.AccountSettings, nil
+}
+
+func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("account_id"), req, resp)
+}
+
+func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state SettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ResetAccountSettings(ctx, &datafy.ResetAccountSettingsRequest{
+		AccountId: state.AccountId.ValueString(),
+	})
+	if datafy.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error delete account settings",
+			"Could not reset account settings: "+err.Error(),
+		)
+		return
+	}
+}
@@ -0,0 +1,67 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsModifyPlan_oneThresholdConfigured(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&SettingsResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	s := schemaResp.Schema
+
+	model := func(grow, shrink types.Int64) SettingsResourceModel {
+		return SettingsResourceModel{
+			AccountId:              types.StringValue("acc-123"),
+			AutoscalingEnabled:     types.BoolValue(true),
+			ShrinkEnabled:          types.BoolValue(true),
+			GrowThresholdPercent:   grow,
+			ShrinkThresholdPercent: shrink,
+			NotificationEmail:      types.StringValue(""),
+		}
+	}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, state.Set(ctx, model(types.Int64Value(80), types.Int64Value(30))).HasError())
+
+	modifyPlan := func(configured, planned SettingsResourceModel) *resource.ModifyPlanResponse {
+		config := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		require.False(t, config.Set(ctx, configured).HasError())
+		plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		require.False(t, plan.Set(ctx, planned).HasError())
+
+		req := resource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: s, Raw: config.Raw},
+			Plan:   plan,
+			State:  state,
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		(&SettingsResource{}).ModifyPlan(ctx, req, resp)
+		return resp
+	}
+
+	// Lowering grow_threshold_percent below the shrink threshold in state.
+	resp := modifyPlan(model(types.Int64Value(20), types.Int64Null()), model(types.Int64Value(20), types.Int64Value(30)))
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Threshold", resp.Diagnostics.Errors()[0].Summary())
+	assert.Equal(t, path.Root("grow_threshold_percent"), resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	// Raising shrink_threshold_percent above the grow threshold in state.
+	resp = modifyPlan(model(types.Int64Null(), types.Int64Value(90)), model(types.Int64Value(80), types.Int64Value(90)))
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, path.Root("shrink_threshold_percent"), resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path())
+
+	resp = modifyPlan(model(types.Int64Value(50), types.Int64Null()), model(types.Int64Value(50), types.Int64Value(30)))
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+}
@@ -0,0 +1,69 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/retry"
+)
+
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// waitBackoff is a variable so tests can poll faster.
+var waitBackoff = retry.DefaultBackoff
+
+var (
+	errAccountNotReady   = errors.New("account is not ready")
+	errAccountNotDeleted = errors.New("account still exists")
+)
+
+// waitAccountReady polls the account until it can be read and is active.
+// Reads of a newly created account may return not found for a short while.
+func waitAccountReady(ctx context.Context, client *datafy.Client, accountId string) (*datafy.Account, error) {
+	var account *datafy.Account
+	err := retry.Do(ctx, waitBackoff, func(err error) bool {
+		return errors.Is(err, errAccountNotReady) || datafy.IsNotFound(err)
+	}, func(ctx context.Context) error {
+		gar, err := client.GetAccount(ctx, &datafy.GetAccountRequest{AccountId: accountId})
+		if err != nil {
+			return err
+		}
+		if gar.Account.Status != "" && gar.Account.Status != datafy.AccountStatusActive {
+			return fmt.Errorf("%w: status is %s", errAccountNotReady, gar.Account.Status)
+		}
+		account = &gar.Account
+		return nil
+	})
+	return account, err
+}
+
+// waitAccountDeleted polls the account until the API reports it as not
+// found.
+func waitAccountDeleted(ctx context.Context, client *datafy.Client, accountId string) error {
+	return retry.Do(ctx, waitBackoff, func(err error) bool {
+		return errors.Is(err, errAccountNotDeleted)
+	}, func(ctx context.Context) error {
+		gar, err := client.GetAccount(ctx, &datafy.GetAccountRequest{AccountId: accountId})
+		if datafy.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: status is %s", errAccountNotDeleted, gar.Account.Status)
+	})
+}
+
+// waitErrorDetail explains a wait that ran out of time.
+func waitErrorDetail(err error, timeout time.Duration) string {
+	if errors.Is(err, errAccountNotReady) || errors.Is(err, errAccountNotDeleted) || errors.Is(err, context.DeadlineExceeded) || datafy.IsNotFound(err) {
+		return fmt.Sprintf("timed out after %s, increase the timeout: %s", timeout, err.Error())
+	}
+	return err.Error()
+}
@@ -0,0 +1,105 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	waitBackoff = retry.Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond}
+}
+
+// newDelayedServer serves GetAccount for acc-123 and answers the first
+// pending reads with status until it responds with final.
+func newDelayedServer(t *testing.T, pending int, status string, final int) (*httptest.Server, *int32) {
+	var reads int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/accounts/acc-123" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if int(atomic.AddInt32(&reads, 1)) <= pending {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(datafy.Account{AccountId: "acc-123", Status: status})
+			return
+		}
+		if final != http.StatusOK {
+			w.WriteHeader(final)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(datafy.Account{AccountId: "acc-123", Status: datafy.AccountStatusActive})
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &reads
+}
+
+func TestWaitAccountDeleted(t *testing.T) {
+	ts, reads := newDelayedServer(t, 3, datafy.AccountStatusDeleting, http.StatusNotFound)
+
+	err := waitAccountDeleted(context.Background(), datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(reads))
+}
+
+func TestWaitAccountDeleted_timeout(t *testing.T) {
+	ts, _ := newDelayedServer(t, 1<<30, datafy.AccountStatusDeleting, http.StatusNotFound)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitAccountDeleted(ctx, datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.Error(t, err)
+	assert.Contains(t, waitErrorDetail(err, 20*time.Millisecond), "timed out after 20ms")
+}
+
+func TestWaitAccountDeleted_error(t *testing.T) {
+	ts, reads := newDelayedServer(t, 0, "", http.StatusForbidden)
+
+	err := waitAccountDeleted(context.Background(), datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(reads))
+}
+
+func TestWaitAccountReady(t *testing.T) {
+	ts, reads := newDelayedServer(t, 2, datafy.AccountStatusCreating, http.StatusOK)
+
+	account, err := waitAccountReady(context.Background(), datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, datafy.AccountStatusActive, account.Status)
+	assert.Equal(t, int32(3), atomic.LoadInt32(reads))
+}
+
+func TestWaitAccountReady_notFoundYet(t *testing.T) {
+	var reads int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&reads, 1) == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(datafy.Account{AccountId: "acc-123"})
+	}))
+	defer ts.Close()
+
+	account, err := waitAccountReady(context.Background(), datafy.NewClient("dummy", ts.URL), "acc-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acc-123", account.AccountId)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&reads))
+}
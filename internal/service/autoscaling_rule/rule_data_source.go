@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,10 +25,15 @@ type DataSource struct {
 }
 
 type DataSourceModel struct {
-	AccountId types.String         `tfsdk:"account_id"`
-	RuleId    types.String         `tfsdk:"rule_id"`
-	Active    types.Bool           `tfsdk:"active"`
-	Rule      jsontypes.Normalized `tfsdk:"rule"`
+	AccountId       types.String         `tfsdk:"account_id"`
+	RuleId          types.String         `tfsdk:"rule_id"`
+	Name            types.String         `tfsdk:"name"`
+	Description     types.String         `tfsdk:"description"`
+	Priority        types.Int64          `tfsdk:"priority"`
+	Active          types.Bool           `tfsdk:"active"`
+	Schedule        types.Object         `tfsdk:"schedule"`
+	EffectiveActive types.Bool           `tfsdk:"effective_active"`
+	Rule            jsontypes.Normalized `tfsdk:"rule"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,10 +52,55 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Description: "The unique identifier of the autoscaling rule to look up.",
 				Required:    true,
 			},
+			"name": schema.StringAttribute{
+				Description: "The display name of the autoscaling rule.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The human-readable description of the autoscaling rule.",
+				Computed:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "The precedence of the rule when several rules of the account match the same volume.",
+				Computed:    true,
+			},
 			"active": schema.BoolAttribute{
 				Description: "Whether the autoscaling rule is currently active.",
 				Computed:    true,
 			},
+			"schedule": schema.SingleNestedAttribute{
+				Description: "The activation schedule of the rule, if any.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"cron": schema.StringAttribute{
+						Description: "The cron expression marking the start of each activation window.",
+						Computed:    true,
+					},
+					"duration": schema.StringAttribute{
+						CustomType:  timetypes.GoDurationType{},
+						Description: "How long each activation window lasts.",
+						Computed:    true,
+					},
+					"timezone": schema.StringAttribute{
+						Description: "The IANA time zone used to evaluate `cron`.",
+						Computed:    true,
+					},
+					"active_from": schema.StringAttribute{
+						CustomType:  timetypes.RFC3339Type{},
+						Description: "The time, in RFC 3339 format, from which the rule is in force.",
+						Computed:    true,
+					},
+					"active_until": schema.StringAttribute{
+						CustomType:  timetypes.RFC3339Type{},
+						Description: "The time, in RFC 3339 format, until which the rule is in force.",
+						Computed:    true,
+					},
+				},
+			},
+			"effective_active": schema.BoolAttribute{
+				Description: "Whether the rule is currently in force, taking both `active` and `schedule` into account.",
+				Computed:    true,
+			},
 			"rule": schema.StringAttribute{
 				CustomType:  jsontypes.NormalizedType{},
 				Description: "The autoscaling rule policy as a JSON string.",
@@ -64,17 +116,17 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*datafy.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *datafy.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -99,7 +151,15 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 	plan.AccountId = types.StringValue(gaarr.AutoscalingRule.AccountId)
 	plan.RuleId = types.StringValue(gaarr.AutoscalingRule.RuleId)
+	plan.Name = types.StringValue(gaarr.AutoscalingRule.Name)
+	plan.Description = types.StringValue(gaarr.AutoscalingRule.Description)
+	plan.Priority = types.Int64Value(gaarr.AutoscalingRule.Priority)
 	plan.Active = types.BoolValue(gaarr.AutoscalingRule.Active)
+	plan.EffectiveActive = types.BoolValue(gaarr.AutoscalingRule.EffectiveActive)
+
+	schedule, diags := flattenSchedule(gaarr.AutoscalingRule.Schedule)
+	resp.Diagnostics.Append(diags...)
+	plan.Schedule = schedule
 	plan.Rule = jsontypes.NewNormalizedValue(string(gaarr.AutoscalingRule.Rule))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -0,0 +1,137 @@
+package autoscaling_rule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &PreviewDataSource{}
+
+func NewPreviewDataSource() datasource.DataSource {
+	return &PreviewDataSource{}
+}
+
+type PreviewDataSource struct {
+	client *datafy.Client
+}
+
+type PreviewDataSourceModel struct {
+	AccountId    types.String         `tfsdk:"account_id"`
+	Rule         jsontypes.Normalized `tfsdk:"rule"`
+	VolumeIds    types.List           `tfsdk:"volume_ids"`
+	InstanceIds  types.List           `tfsdk:"instance_ids"`
+	VolumeCount  types.Int64          `tfsdk:"volume_count"`
+	TotalSizeGiB types.Int64          `tfsdk:"total_size_gib"`
+}
+
+func (d *PreviewDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_autoscaling_rule_preview"
+}
+
+func (d *PreviewDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to preview which volumes a candidate autoscaling rule would match, without creating the rule. For more information about autoscaling rules, see the [Datafy documentation](https://docs.datafy.io/volume-lifecycle/autoscaling-rules).",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account whose volumes are evaluated.",
+				Required:    true,
+			},
+			"rule": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Description: "The candidate autoscaling rule policy as a JSON string using JsonLogic syntax. Use `jsonencode()` to construct the value.",
+				Required:    true,
+			},
+			"volume_ids": schema.ListAttribute{
+				Description: "The IDs of the EBS volumes matched by the rule.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"instance_ids": schema.ListAttribute{
+				Description: "The IDs of the EC2 instances the matched volumes are attached to.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"volume_count": schema.Int64Attribute{
+				Description: "The number of EBS volumes matched by the rule.",
+				Computed:    true,
+			},
+			"total_size_gib": schema.Int64Attribute{
+				Description: "The total size of the matched volumes, in GiB.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PreviewDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *PreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan PreviewDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	paarr, err := d.client.PreviewAccountAutoscalingRule(ctx, &datafy.PreviewAccountAutoscalingRuleRequest{
+		AccountId: plan.AccountId.ValueString(),
+		Rule:      json.RawMessage(plan.Rule.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error preview account autoscaling rule",
+			"Could not preview account autoscaling rule: "+err.Error(),
+		)
+		return
+	}
+
+	volumeIds, diags := types.ListValueFrom(ctx, types.StringType, nonNil(paarr.AutoscalingRulePreview.VolumeIds))
+	resp.Diagnostics.Append(diags...)
+	instanceIds, diags := types.ListValueFrom(ctx, types.StringType, nonNil(paarr.AutoscalingRulePreview.InstanceIds))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.VolumeIds = volumeIds
+	plan.InstanceIds = instanceIds
+	plan.VolumeCount = types.Int64Value(int64(len(paarr.AutoscalingRulePreview.VolumeIds)))
+	plan.TotalSizeGiB = types.Int64Value(paarr.AutoscalingRulePreview.TotalSizeGiB)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// nonNil turns a missing list in the API response into an empty one so the
+// attribute is never null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -7,19 +7,27 @@ import (
 	"strings"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/datafy-io/terraform-provider-datafy/internal/rulelogic"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.ResourceWithConfigure   = &Resource{}
-	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithImportState    = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -27,14 +35,20 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client *datafy.Client
+	client       *datafy.Client
+	providerData *providerdata.ProviderData
 }
 
 type ResourceModel struct {
-	AccountId types.String         `tfsdk:"account_id"`
-	RuleId    types.String         `tfsdk:"rule_id"`
-	Active    types.Bool           `tfsdk:"active"`
-	Rule      jsontypes.Normalized `tfsdk:"rule"`
+	AccountId       types.String         `tfsdk:"account_id"`
+	RuleId          types.String         `tfsdk:"rule_id"`
+	Name            types.String         `tfsdk:"name"`
+	Description     types.String         `tfsdk:"description"`
+	Priority        types.Int64          `tfsdk:"priority"`
+	Active          types.Bool           `tfsdk:"active"`
+	Schedule        types.Object         `tfsdk:"schedule"`
+	EffectiveActive types.Bool           `tfsdk:"effective_active"`
+	Rule            jsontypes.Normalized `tfsdk:"rule"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,9 +60,11 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 		Description: "Manages a Datafy autoscaling rule. Autoscaling rules define policies that control which volumes are eligible for autoscaling within a Datafy account. For more information about autoscaling rules, see the [Datafy documentation](https://docs.datafy.io/volume-lifecycle/autoscaling-rules).",
 		Attributes: map[string]schema.Attribute{
 			"account_id": schema.StringAttribute{
-				Description: "The unique identifier of the Datafy account. Changing this forces a new rule to be created.",
-				Required:    true,
+				Description: "The unique identifier of the Datafy account. Changing this forces a new rule to be created. Defaults to the provider's `default_account_id`.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -59,10 +75,65 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"name": schema.StringAttribute{
+				Description: "The display name of the autoscaling rule. Defaults to an empty string.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"description": schema.StringAttribute{
+				Description: "A human-readable description of the autoscaling rule. Defaults to an empty string.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"priority": schema.Int64Attribute{
+				Description: "The precedence of the rule when several rules of the account match the same volume. Must be unique within the account. If omitted, the priority is assigned by Datafy.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"active": schema.BoolAttribute{
 				Description: "Whether the autoscaling rule is currently active. When `false`, the rule exists but is not enforced.",
 				Required:    true,
 			},
+			"schedule": schema.SingleNestedAttribute{
+				Description: "Restricts when an active rule is in force. Set either `cron` and `duration` for a recurring window, or `active_from` and/or `active_until` for a fixed time range. If omitted, an active rule is always in force.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"cron": schema.StringAttribute{
+						Description: "A five-field cron expression (`minute hour day-of-month month day-of-week`) marking the start of each activation window, e.g. `\"0 2 * * SAT\"`.",
+						Optional:    true,
+					},
+					"duration": schema.StringAttribute{
+						CustomType:  timetypes.GoDurationType{},
+						Description: "How long each activation window lasts, specified as a Go duration string in whole minutes (e.g., `\"30m\"`, `\"4h\"`). Required when `cron` is set.",
+						Optional:    true,
+					},
+					"timezone": schema.StringAttribute{
+						Description: "The IANA time zone used to evaluate `cron`, e.g. `\"Europe/Berlin\"`. Defaults to `UTC`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("UTC"),
+					},
+					"active_from": schema.StringAttribute{
+						CustomType:  timetypes.RFC3339Type{},
+						Description: "The time, in RFC 3339 format, from which the rule is in force.",
+						Optional:    true,
+					},
+					"active_until": schema.StringAttribute{
+						CustomType:  timetypes.RFC3339Type{},
+						Description: "The time, in RFC 3339 format, until which the rule is in force.",
+						Optional:    true,
+					},
+				},
+			},
+			"effective_active": schema.BoolAttribute{
+				Description: "Whether the rule is currently in force, taking both `active` and `schedule` into account.",
+				Computed:    true,
+			},
 			"rule": schema.StringAttribute{
 				CustomType:  jsontypes.NormalizedType{},
 				Description: "The autoscaling rule policy as a JSON string using JsonLogic syntax. The rule defines conditions for matching volumes based on available parameters: `instance_id` (EC2 instance ID), `node_group_name` (Kubernetes node group name), `cluster_name` (cluster name), `tags` (volume tags in key:value format), and `instance_tags` (EC2 instance tags in key:value format). Use `jsonencode()` to construct the value.",
@@ -78,17 +149,111 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*datafy.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *datafy.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.providerData = data
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var schedule types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schedule"), &schedule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSchedule(ctx, schedule)...)
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if providerdata.PlanAccountId(ctx, r.providerData, req, resp) {
+		resp.RequiresReplace.Append(path.Root("account_id"))
+	}
+
+	// Nothing to validate on destroy.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AccountId.IsUnknown() {
+		return
+	}
+
+	checkPriority := !plan.Priority.IsUnknown() && !plan.Priority.IsNull()
+	checkOverlap := !plan.Rule.IsUnknown() && !plan.Active.IsUnknown() && plan.Active.ValueBool()
+
+	if !req.State.Raw.IsNull() {
+		var state ResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.AccountId.Equal(plan.AccountId) {
+			checkPriority = checkPriority && !state.Priority.Equal(plan.Priority)
+			checkOverlap = checkOverlap && (!state.Active.Equal(plan.Active) || !state.Rule.Equal(plan.Rule))
+		}
+	}
+
+	if !checkPriority && !checkOverlap {
+		return
+	}
+
+	laarr, err := r.client.ListAccountAutoscalingRules(ctx, &datafy.ListAccountAutoscalingRulesRequest{
+		AccountId: plan.AccountId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error list account autoscaling rules",
+			"Could not list account autoscaling rules: "+err.Error(),
+		)
+		return
+	}
+
+	var overlapping []string
+	for _, rule := range laarr.AutoscalingRules {
+		if rule.RuleId == plan.RuleId.ValueString() {
+			continue
+		}
+
+		if checkPriority && rule.Priority == plan.Priority.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("priority"),
+				"Duplicate Autoscaling Rule Priority",
+				fmt.Sprintf("Priority %d is already used by autoscaling rule %s of account %s. Priorities must be unique within an account.", rule.Priority, rule.RuleId, plan.AccountId.ValueString()),
+			)
+			return
+		}
+
+		if checkOverlap && rule.Active {
+			// Rules outside the documented JsonLogic subset cannot be
+			// analyzed, so they are never reported as overlapping.
+			if overlap, err := rulelogic.Overlap(json.RawMessage(plan.Rule.ValueString()), rule.Rule); err == nil && overlap {
+				overlapping = append(overlapping, rule.RuleId)
+			}
+		}
+	}
+
+	if len(overlapping) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rule"),
+			"Overlapping Autoscaling Rules",
+			fmt.Sprintf("The conditions of this rule can match the same volumes as the active autoscaling rules %s of account %s. Set distinct `priority` values or narrow the conditions to make precedence explicit.", strings.Join(overlapping, ", "), plan.AccountId.ValueString()),
+		)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,10 +264,20 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	schedule, diags := expandSchedule(ctx, plan.Schedule)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	caarr, err := r.client.CreateAccountAutoscalingRule(ctx, &datafy.CreateAccountAutoscalingRuleRequest{
-		AccountId: plan.AccountId.ValueString(),
-		Active:    plan.Active.ValueBool(),
-		Rule:      json.RawMessage(plan.Rule.ValueString()),
+		AccountId:   plan.AccountId.ValueString(),
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Priority:    configuredPriority(plan.Priority),
+		Active:      plan.Active.ValueBool(),
+		Schedule:    schedule,
+		Rule:        json.RawMessage(plan.Rule.ValueString()),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -114,7 +289,13 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	plan.AccountId = types.StringValue(caarr.AutoscalingRule.AccountId)
 	plan.RuleId = types.StringValue(caarr.AutoscalingRule.RuleId)
+	plan.Name = types.StringValue(caarr.AutoscalingRule.Name)
+	plan.Description = types.StringValue(caarr.AutoscalingRule.Description)
+	plan.Priority = types.Int64Value(caarr.AutoscalingRule.Priority)
 	plan.Active = types.BoolValue(caarr.AutoscalingRule.Active)
+	plan.EffectiveActive = types.BoolValue(caarr.AutoscalingRule.EffectiveActive)
+	plan.Schedule, diags = flattenSchedule(caarr.AutoscalingRule.Schedule)
+	resp.Diagnostics.Append(diags...)
 	plan.Rule = jsontypes.NewNormalizedValue(string(caarr.AutoscalingRule.Rule))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -128,6 +309,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	var diags diag.Diagnostics
 	gaarr, err := r.client.GetAccountAutoscalingRule(ctx, &datafy.GetAccountAutoscalingRuleRequest{
 		AccountId: state.AccountId.ValueString(),
 		RuleId:    state.RuleId.ValueString(),
@@ -142,7 +324,13 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	state.AccountId = types.StringValue(gaarr.AutoscalingRule.AccountId)
 	state.RuleId = types.StringValue(gaarr.AutoscalingRule.RuleId)
+	state.Name = types.StringValue(gaarr.AutoscalingRule.Name)
+	state.Description = types.StringValue(gaarr.AutoscalingRule.Description)
+	state.Priority = types.Int64Value(gaarr.AutoscalingRule.Priority)
 	state.Active = types.BoolValue(gaarr.AutoscalingRule.Active)
+	state.EffectiveActive = types.BoolValue(gaarr.AutoscalingRule.EffectiveActive)
+	state.Schedule, diags = flattenSchedule(gaarr.AutoscalingRule.Schedule)
+	resp.Diagnostics.Append(diags...)
 	state.Rule = jsontypes.NewNormalizedValue(string(gaarr.AutoscalingRule.Rule))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -156,11 +344,23 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	_, err := r.client.UpdateAccountAutoscalingRule(ctx, &datafy.UpdateAccountAutoscalingRuleRequest{
-		AccountId: plan.AccountId.ValueString(),
-		RuleId:    plan.RuleId.ValueString(),
-		Active:    plan.Active.ValueBool(),
-		Rule:      json.RawMessage(plan.Rule.ValueString()),
+	schedule, diags := expandSchedule(ctx, plan.Schedule)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uaarr, err := r.client.UpdateAccountAutoscalingRule(ctx, &datafy.UpdateAccountAutoscalingRuleRequest{
+		AccountId:   plan.AccountId.ValueString(),
+		RuleId:      plan.RuleId.ValueString(),
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+		Priority:    configuredPriority(plan.Priority),
+		Active:      plan.Active.ValueBool(),
+		Schedule:    schedule,
+		// The resource manages the schedule, so a removed one is cleared.
+		ClearSchedule: true,
+		Rule:          json.RawMessage(plan.Rule.ValueString()),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -170,6 +370,9 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	plan.Priority = types.Int64Value(uaarr.AutoscalingRule.Priority)
+	plan.EffectiveActive = types.BoolValue(uaarr.AutoscalingRule.EffectiveActive)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -207,3 +410,13 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 }
+
+// configuredPriority returns the priority to send to the API, or nil to let
+// Datafy assign one. An unconfigured priority is unknown on create, for which
+// ValueInt64Pointer would return 0.
+func configuredPriority(priority types.Int64) *int64 {
+	if priority.IsNull() || priority.IsUnknown() {
+		return nil
+	}
+	return priority.ValueInt64Pointer()
+}
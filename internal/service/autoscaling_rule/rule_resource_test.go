@@ -0,0 +1,83 @@
+package autoscaling_rule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfiguredPriority(t *testing.T) {
+	assert.Nil(t, configuredPriority(types.Int64Unknown()))
+	assert.Nil(t, configuredPriority(types.Int64Null()))
+	assert.Equal(t, int64(10), *configuredPriority(types.Int64Value(10)))
+}
+
+func TestCreate_withoutPriority(t *testing.T) {
+	ctx := context.Background()
+
+	// The API assigns the next free priority when none is sent.
+	var gotBodies []map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotBodies = append(gotBodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(datafy.AutoscalingRule{
+			AccountId: "acc-123",
+			RuleId:    fmt.Sprintf("rule-%d", len(gotBodies)),
+			Priority:  int64(len(gotBodies)),
+			Active:    true,
+			Rule:      json.RawMessage(`{"max":10,"min":1}`),
+		})
+	}))
+	defer ts.Close()
+
+	r := &Resource{client: datafy.NewClient("dummy", ts.URL)}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	s := schemaResp.Schema
+
+	var priorities []int64
+	for i := 0; i < 2; i++ {
+		plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		require.False(t, plan.Set(ctx, &ResourceModel{
+			AccountId:       types.StringValue("acc-123"),
+			RuleId:          types.StringUnknown(),
+			Name:            types.StringValue(""),
+			Description:     types.StringValue(""),
+			Priority:        types.Int64Unknown(),
+			Active:          types.BoolValue(true),
+			Schedule:        types.ObjectNull(scheduleAttrTypes),
+			EffectiveActive: types.BoolUnknown(),
+			Rule:            jsontypes.NewNormalizedValue(`{"max":10,"min":1}`),
+		}).HasError())
+
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var state ResourceModel
+		require.False(t, resp.State.Get(ctx, &state).HasError())
+		priorities = append(priorities, state.Priority.ValueInt64())
+	}
+
+	require.Len(t, gotBodies, 2)
+	for _, body := range gotBodies {
+		assert.NotContains(t, body, "priority")
+	}
+	assert.Equal(t, []int64{1, 2}, priorities)
+}
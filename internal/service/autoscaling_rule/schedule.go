@@ -0,0 +1,258 @@
+package autoscaling_rule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type ScheduleModel struct {
+	Cron        types.String         `tfsdk:"cron"`
+	Duration    timetypes.GoDuration `tfsdk:"duration"`
+	Timezone    types.String         `tfsdk:"timezone"`
+	ActiveFrom  timetypes.RFC3339    `tfsdk:"active_from"`
+	ActiveUntil timetypes.RFC3339    `tfsdk:"active_until"`
+}
+
+var scheduleAttrTypes = map[string]attr.Type{
+	"cron":         types.StringType,
+	"duration":     timetypes.GoDurationType{},
+	"timezone":     types.StringType,
+	"active_from":  timetypes.RFC3339Type{},
+	"active_until": timetypes.RFC3339Type{},
+}
+
+// validateSchedule checks a configured schedule locally so mistakes surface
+// during plan instead of as an API error during apply.
+func validateSchedule(ctx context.Context, obj types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if obj.IsNull() || obj.IsUnknown() {
+		return diags
+	}
+
+	var schedule ScheduleModel
+	diags.Append(obj.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	root := path.Root("schedule")
+	hasCron := !schedule.Cron.IsNull()
+	hasRange := !schedule.ActiveFrom.IsNull() || !schedule.ActiveUntil.IsNull()
+
+	if !hasCron && !hasRange {
+		diags.AddAttributeError(root, "Invalid Schedule",
+			"A schedule must set either `cron` and `duration`, or at least one of `active_from` and `active_until`.")
+		return diags
+	}
+	if hasCron && hasRange {
+		diags.AddAttributeError(root, "Invalid Schedule",
+			"`cron` cannot be combined with `active_from` or `active_until`.")
+		return diags
+	}
+
+	if hasCron {
+		if schedule.Duration.IsNull() {
+			diags.AddAttributeError(root.AtName("duration"), "Missing Schedule Duration",
+				"`duration` is required when `cron` is set.")
+		}
+		if !schedule.Cron.IsUnknown() {
+			if err := validateCron(schedule.Cron.ValueString()); err != nil {
+				diags.AddAttributeError(root.AtName("cron"), "Invalid Cron Expression", err.Error())
+			}
+		}
+	} else if !schedule.Duration.IsNull() {
+		diags.AddAttributeError(root.AtName("duration"), "Invalid Schedule",
+			"`duration` can only be used together with `cron`.")
+	}
+
+	if !schedule.Duration.IsNull() && !schedule.Duration.IsUnknown() {
+		d, d2 := schedule.Duration.ValueGoDuration()
+		diags.Append(d2...)
+		if !d2.HasError() && (d < time.Minute || d%time.Minute != 0) {
+			diags.AddAttributeError(root.AtName("duration"), "Invalid Schedule Duration",
+				fmt.Sprintf("`duration` must be a positive whole number of minutes, got %q.", schedule.Duration.ValueString()))
+		}
+	}
+
+	if !schedule.Timezone.IsNull() && !schedule.Timezone.IsUnknown() {
+		if _, err := time.LoadLocation(schedule.Timezone.ValueString()); err != nil {
+			diags.AddAttributeError(root.AtName("timezone"), "Invalid Schedule Timezone",
+				fmt.Sprintf("%q is not a valid IANA time zone name.", schedule.Timezone.ValueString()))
+		}
+	}
+
+	if !schedule.ActiveFrom.IsNull() && !schedule.ActiveFrom.IsUnknown() &&
+		!schedule.ActiveUntil.IsNull() && !schedule.ActiveUntil.IsUnknown() {
+		from, d1 := schedule.ActiveFrom.ValueRFC3339Time()
+		until, d2 := schedule.ActiveUntil.ValueRFC3339Time()
+		diags.Append(d1...)
+		diags.Append(d2...)
+		if !d1.HasError() && !d2.HasError() && !from.Before(until) {
+			diags.AddAttributeError(root.AtName("active_until"), "Invalid Schedule Range",
+				"`active_until` must be after `active_from`.")
+		}
+	}
+
+	return diags
+}
+
+func expandSchedule(ctx context.Context, obj types.Object) (*datafy.AutoscalingRuleSchedule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, diags
+	}
+
+	var schedule ScheduleModel
+	diags.Append(obj.As(ctx, &schedule, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	res := &datafy.AutoscalingRuleSchedule{
+		Cron:     schedule.Cron.ValueString(),
+		Timezone: schedule.Timezone.ValueString(),
+	}
+	if !schedule.Duration.IsNull() {
+		d, d2 := schedule.Duration.ValueGoDuration()
+		diags.Append(d2...)
+		res.DurationMinutes = int64(d.Minutes())
+	}
+	if !schedule.ActiveFrom.IsNull() {
+		t, d2 := schedule.ActiveFrom.ValueRFC3339Time()
+		diags.Append(d2...)
+		res.ActiveFrom = &t
+	}
+	if !schedule.ActiveUntil.IsNull() {
+		t, d2 := schedule.ActiveUntil.ValueRFC3339Time()
+		diags.Append(d2...)
+		res.ActiveUntil = &t
+	}
+
+	return res, diags
+}
+
+func flattenSchedule(schedule *datafy.AutoscalingRuleSchedule) (types.Object, diag.Diagnostics) {
+	if schedule == nil {
+		return types.ObjectNull(scheduleAttrTypes), nil
+	}
+
+	cron := types.StringNull()
+	duration := timetypes.NewGoDurationNull()
+	if schedule.Cron != "" {
+		cron = types.StringValue(schedule.Cron)
+		duration = timetypes.NewGoDurationValue(time.Duration(schedule.DurationMinutes) * time.Minute)
+	}
+
+	timezone := schedule.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	return types.ObjectValue(scheduleAttrTypes, map[string]attr.Value{
+		"cron":         cron,
+		"duration":     duration,
+		"timezone":     types.StringValue(timezone),
+		"active_from":  timetypes.NewRFC3339TimePointerValue(schedule.ActiveFrom),
+		"active_until": timetypes.NewRFC3339TimePointerValue(schedule.ActiveUntil),
+	})
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// validateCron checks a standard five-field cron expression. Each field
+// accepts `*`, numbers, ranges (`a-b`), steps (`*/n`, `a-b/n`) and
+// comma-separated lists; months and weekdays also accept three-letter names.
+func validateCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected 5 space-separated fields (minute hour day-of-month month day-of-week), got %d in %q", len(fields), expr)
+	}
+
+	for i, field := range fields {
+		spec := cronFields[i]
+		for _, part := range strings.Split(field, ",") {
+			if err := validateCronPart(part, spec); err != nil {
+				return fmt.Errorf("invalid %s field %q: %s", spec.name, field, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateCronPart(part string, spec cronField) error {
+	rng, step, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("step %q must be a positive number", step)
+		}
+	}
+
+	if rng == "*" {
+		return nil
+	}
+
+	lo, hi, isRange := strings.Cut(rng, "-")
+	from, err := cronValue(lo, spec)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		if hasStep {
+			return fmt.Errorf("step requires `*` or a range")
+		}
+		return nil
+	}
+
+	to, err := cronValue(hi, spec)
+	if err != nil {
+		return err
+	}
+	if from > to {
+		return fmt.Errorf("range %q is reversed", rng)
+	}
+
+	return nil
+}
+
+func cronValue(s string, spec cronField) (int, error) {
+	for i, name := range spec.names {
+		if strings.EqualFold(s, name) {
+			return i + spec.min, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", n, spec.min, spec.max)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,84 @@
+package autoscaling_rule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"0 2 * * 6",
+		"0 2 * * SAT",
+		"*/15 0-6 1,15 JAN-MAR mon-fri",
+		"30 22 * * 0-7/2",
+	}
+	for _, expr := range valid {
+		assert.NoError(t, validateCron(expr), expr)
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"5/10 * * * *",
+		"x * * * *",
+	}
+	for _, expr := range invalid {
+		assert.Error(t, validateCron(expr), expr)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	ctx := context.Background()
+	schedule := func(cron, duration, timezone, from, until string) types.Object {
+		str := func(s string) types.String {
+			if s == "" {
+				return types.StringNull()
+			}
+			return types.StringValue(s)
+		}
+		dur := timetypes.NewGoDurationNull()
+		if duration != "" {
+			dur = timetypes.NewGoDurationValueFromStringMust(duration)
+		}
+		ts := func(s string) timetypes.RFC3339 {
+			if s == "" {
+				return timetypes.NewRFC3339Null()
+			}
+			return timetypes.NewRFC3339ValueMust(s)
+		}
+		return types.ObjectValueMust(scheduleAttrTypes, map[string]attr.Value{
+			"cron":         str(cron),
+			"duration":     dur,
+			"timezone":     str(timezone),
+			"active_from":  ts(from),
+			"active_until": ts(until),
+		})
+	}
+
+	assert.False(t, validateSchedule(ctx, types.ObjectNull(scheduleAttrTypes)).HasError())
+	assert.False(t, validateSchedule(ctx, schedule("0 2 * * SAT", "4h", "Europe/Berlin", "", "")).HasError())
+	assert.False(t, validateSchedule(ctx, schedule("", "", "UTC", "2026-01-01T00:00:00Z", "")).HasError())
+	assert.False(t, validateSchedule(ctx, schedule("", "", "UTC", "2026-01-01T00:00:00Z", "2026-02-01T00:00:00Z")).HasError())
+
+	assert.True(t, validateSchedule(ctx, schedule("", "", "UTC", "", "")).HasError(), "empty schedule")
+	assert.True(t, validateSchedule(ctx, schedule("0 2 * * SAT", "", "UTC", "", "")).HasError(), "cron without duration")
+	assert.True(t, validateSchedule(ctx, schedule("0 2 * * SAT", "90s", "UTC", "", "")).HasError(), "duration not in minutes")
+	assert.True(t, validateSchedule(ctx, schedule("0 2 * * SAT", "4h", "Mars/Olympus", "", "")).HasError(), "unknown timezone")
+	assert.True(t, validateSchedule(ctx, schedule("0 2 * * SAT", "4h", "UTC", "2026-01-01T00:00:00Z", "")).HasError(), "cron and range")
+	assert.True(t, validateSchedule(ctx, schedule("", "4h", "UTC", "2026-01-01T00:00:00Z", "")).HasError(), "duration without cron")
+	assert.True(t, validateSchedule(ctx, schedule("", "", "UTC", "2026-02-01T00:00:00Z", "2026-01-01T00:00:00Z")).HasError(), "reversed range")
+}
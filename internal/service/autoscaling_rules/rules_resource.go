@@ -0,0 +1,580 @@
+package autoscaling_rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/datafy-io/terraform-provider-datafy/internal/rulelogic"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithImportState    = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
+)
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client       *datafy.Client
+	providerData *providerdata.ProviderData
+}
+
+type ResourceModel struct {
+	AccountId types.String `tfsdk:"account_id"`
+	Rules     []RuleModel  `tfsdk:"rules"`
+}
+
+type RuleModel struct {
+	RuleId      types.String         `tfsdk:"rule_id"`
+	Name        types.String         `tfsdk:"name"`
+	Description types.String         `tfsdk:"description"`
+	Priority    types.Int64          `tfsdk:"priority"`
+	Active      types.Bool           `tfsdk:"active"`
+	Rule        jsontypes.Normalized `tfsdk:"rule"`
+}
+
+func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_autoscaling_rules"
+}
+
+func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exclusively manages the full set of autoscaling rules of a Datafy account. Rules that exist in the account but are not listed in `rules` are deleted on apply. Do not use this resource together with `datafy_autoscaling_rule` for the same account. For more information about autoscaling rules, see the [Datafy documentation](https://docs.datafy.io/volume-lifecycle/autoscaling-rules).",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account whose rules are managed. Changing this forces a new resource to be created. Defaults to the provider's `default_account_id`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "The complete list of autoscaling rules of the account. Any rule of the account that is not listed here is deleted.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rule_id": schema.StringAttribute{
+							Description: "The unique identifier of the autoscaling rule.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The display name of the autoscaling rule. Defaults to an empty string.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+						"description": schema.StringAttribute{
+							Description: "A human-readable description of the autoscaling rule. Defaults to an empty string.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+						"priority": schema.Int64Attribute{
+							Description: "The precedence of the rule when several rules of the account match the same volume. Must be unique within the list. If omitted, the priority is assigned by Datafy.",
+							Optional:    true,
+							Computed:    true,
+						},
+						"active": schema.BoolAttribute{
+							Description: "Whether the autoscaling rule is currently active. When `false`, the rule exists but is not enforced.",
+							Required:    true,
+						},
+						"rule": schema.StringAttribute{
+							CustomType:  jsontypes.NormalizedType{},
+							Description: "The autoscaling rule policy as a JSON string using JsonLogic syntax. Use `jsonencode()` to construct the value.",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rules types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	if resp.Diagnostics.HasError() || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	var config []RuleModel
+	resp.Diagnostics.Append(rules.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[int64]int, len(config))
+	for i, rule := range config {
+		if rule.Priority.IsNull() || rule.Priority.IsUnknown() {
+			continue
+		}
+		if j, ok := seen[rule.Priority.ValueInt64()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("priority"),
+				"Duplicate Autoscaling Rule Priority",
+				fmt.Sprintf("Priority %d is already used by rules[%d]. Priorities must be unique within an account.", rule.Priority.ValueInt64(), j),
+			)
+			continue
+		}
+		seen[rule.Priority.ValueInt64()] = i
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if providerdata.PlanAccountId(ctx, r.providerData, req, resp) {
+		resp.RequiresReplace.Append(path.Root("account_id"))
+	}
+
+	// Nothing to reconcile on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var accountId types.String
+	var rules types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("account_id"), &accountId)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	if resp.Diagnostics.HasError() || accountId.IsUnknown() || rules.IsUnknown() {
+		return
+	}
+
+	var plan ResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var existing []RuleModel
+	if !req.State.Raw.IsNull() {
+		var state ResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.AccountId.Equal(plan.AccountId) {
+			existing = state.Rules
+		}
+	}
+	if existing == nil && r.client != nil {
+		laarr, err := r.client.ListAccountAutoscalingRules(ctx, &datafy.ListAccountAutoscalingRulesRequest{
+			AccountId: plan.AccountId.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error list account autoscaling rules",
+				"Could not list account autoscaling rules: "+err.Error(),
+			)
+			return
+		}
+		existing = toRuleModels(laarr.AutoscalingRules)
+	}
+
+	var config ResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i := range plan.Rules {
+		plan.Rules[i].RuleId = types.StringUnknown()
+		if i < len(config.Rules) && config.Rules[i].Priority.IsNull() {
+			plan.Rules[i].Priority = types.Int64Unknown()
+		}
+	}
+
+	claimed := matchRules(plan.Rules, existing)
+
+	// Rules without a configured priority keep the one Datafy assigned.
+	for i := range plan.Rules {
+		if !plan.Rules[i].Priority.IsUnknown() || plan.Rules[i].RuleId.IsUnknown() {
+			continue
+		}
+		for _, er := range existing {
+			if er.RuleId.Equal(plan.Rules[i].RuleId) {
+				plan.Rules[i].Priority = er.Priority
+				break
+			}
+		}
+	}
+
+	var removed []string
+	for j, er := range existing {
+		if !claimed[j] {
+			removed = append(removed, er.RuleId.ValueString())
+		}
+	}
+	if len(removed) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Autoscaling rules will be deleted",
+			fmt.Sprintf("The following autoscaling rules of account %s are not in the configuration and will be deleted: %s", plan.AccountId.ValueString(), strings.Join(removed, ", ")),
+		)
+	}
+
+	for i := range plan.Rules {
+		for j := i + 1; j < len(plan.Rules); j++ {
+			if rulesOverlap(plan.Rules[i], plan.Rules[j]) {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("rules").AtListIndex(j).AtName("rule"),
+					"Overlapping Autoscaling Rules",
+					fmt.Sprintf("The conditions of %s and %s can match the same volumes. Set distinct `priority` values or narrow the conditions to make precedence explicit.", ruleLabel(plan.Rules, i), ruleLabel(plan.Rules, j)),
+				)
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := r.reconcile(ctx, plan.AccountId.ValueString(), plan.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Rules = rules
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	laarr, err := r.client.ListAccountAutoscalingRules(ctx, &datafy.ListAccountAutoscalingRulesRequest{
+		AccountId: state.AccountId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error read account autoscaling rules",
+			"Could not read account autoscaling rules: "+err.Error(),
+		)
+		return
+	}
+
+	// Keep the order of the rules already in state so that the configuration
+	// order does not produce a diff, and append rules created outside of
+	// Terraform at the end so they show up as removals in the next plan.
+	remote := toRuleModels(laarr.AutoscalingRules)
+	seen := make(map[string]bool, len(remote))
+	rules := make([]RuleModel, 0, len(remote))
+	for _, sr := range state.Rules {
+		for _, rr := range remote {
+			if rr.RuleId.Equal(sr.RuleId) {
+				rules = append(rules, rr)
+				seen[rr.RuleId.ValueString()] = true
+				break
+			}
+		}
+	}
+	for _, rr := range remote {
+		if !seen[rr.RuleId.ValueString()] {
+			rules = append(rules, rr)
+		}
+	}
+
+	state.Rules = rules
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := r.reconcile(ctx, plan.AccountId.ValueString(), plan.Rules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Rules = rules
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("account_id"), req, resp)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, rule := range state.Rules {
+		_, err := r.client.DeleteAccountAutoscalingRule(ctx, &datafy.DeleteAccountAutoscalingRuleRequest{
+			AccountId: state.AccountId.ValueString(),
+			RuleId:    rule.RuleId.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error delete account autoscaling rule",
+				fmt.Sprintf("Could not delete account autoscaling rule %s: %s", rule.RuleId.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+}
+
+// reconcile makes the rules of the account match desired exactly. Rules
+// whose rule_id is known and still exists are updated in place, the others
+// are created, and every remaining rule of the account is deleted.
+func (r *Resource) reconcile(ctx context.Context, accountId string, desired []RuleModel) ([]RuleModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	laarr, err := r.client.ListAccountAutoscalingRules(ctx, &datafy.ListAccountAutoscalingRulesRequest{
+		AccountId: accountId,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error list account autoscaling rules",
+			"Could not list account autoscaling rules: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	remote := make(map[string]datafy.AutoscalingRule, len(laarr.AutoscalingRules))
+	for _, rule := range laarr.AutoscalingRules {
+		remote[rule.RuleId] = rule
+	}
+
+	keep := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		if id := rule.RuleId.ValueString(); id != "" {
+			if _, ok := remote[id]; ok {
+				keep[id] = true
+			}
+		}
+	}
+
+	for _, rule := range laarr.AutoscalingRules {
+		if keep[rule.RuleId] {
+			continue
+		}
+		_, err := r.client.DeleteAccountAutoscalingRule(ctx, &datafy.DeleteAccountAutoscalingRuleRequest{
+			AccountId: accountId,
+			RuleId:    rule.RuleId,
+		})
+		if err != nil {
+			diags.AddError(
+				"Error delete account autoscaling rule",
+				fmt.Sprintf("Could not delete account autoscaling rule %s: %s", rule.RuleId, err.Error()),
+			)
+			return nil, diags
+		}
+	}
+
+	result := make([]RuleModel, 0, len(desired))
+	for _, rule := range desired {
+		id := rule.RuleId.ValueString()
+		if current, ok := remote[id]; ok && keep[id] {
+			if ruleUnchanged(current, rule) {
+				result = append(result, toRuleModel(current))
+				continue
+			}
+
+			uaarr, err := r.client.UpdateAccountAutoscalingRule(ctx, &datafy.UpdateAccountAutoscalingRuleRequest{
+				AccountId:   accountId,
+				RuleId:      id,
+				Name:        rule.Name.ValueString(),
+				Description: rule.Description.ValueString(),
+				Priority:    configuredPriority(rule.Priority),
+				Active:      rule.Active.ValueBool(),
+				Rule:        json.RawMessage(rule.Rule.ValueString()),
+			})
+			if err != nil {
+				diags.AddError(
+					"Error update account autoscaling rule",
+					fmt.Sprintf("Could not update account autoscaling rule %s: %s", id, err.Error()),
+				)
+				return nil, diags
+			}
+			result = append(result, toRuleModel(uaarr.AutoscalingRule))
+			continue
+		}
+
+		caarr, err := r.client.CreateAccountAutoscalingRule(ctx, &datafy.CreateAccountAutoscalingRuleRequest{
+			AccountId:   accountId,
+			Name:        rule.Name.ValueString(),
+			Description: rule.Description.ValueString(),
+			Priority:    configuredPriority(rule.Priority),
+			Active:      rule.Active.ValueBool(),
+			Rule:        json.RawMessage(rule.Rule.ValueString()),
+		})
+		if err != nil {
+			diags.AddError(
+				"Error creating account autoscaling rule",
+				"Could not create account autoscaling rule: "+err.Error(),
+			)
+			return nil, diags
+		}
+		result = append(result, toRuleModel(caarr.AutoscalingRule))
+	}
+
+	return result, diags
+}
+
+// matchRules sets the rule_id of each planned rule to the rule in existing
+// that it updates, and reports which of the existing rules are claimed.
+// Rules with identical content are matched first, so that reordering the
+// list does not produce updates. An edited rule then keeps the rule_id of the
+// existing rule with the same name or configured priority. Only the rules
+// left after that are paired in list order.
+func matchRules(planned, existing []RuleModel) []bool {
+	claimed := make([]bool, len(existing))
+	match := func(same func(planned, existing RuleModel) bool) {
+		for i := range planned {
+			if !planned[i].RuleId.IsUnknown() {
+				continue
+			}
+			for j := range existing {
+				if !claimed[j] && same(planned[i], existing[j]) {
+					planned[i].RuleId = existing[j].RuleId
+					claimed[j] = true
+					break
+				}
+			}
+		}
+	}
+
+	match(func(p, e RuleModel) bool {
+		return ruleEqual(p.Rule.ValueString(), e.Rule.ValueString())
+	})
+	match(func(p, e RuleModel) bool {
+		return p.Name.ValueString() != "" && p.Name.Equal(e.Name)
+	})
+	match(func(p, e RuleModel) bool {
+		return !p.Priority.IsUnknown() && p.Priority.Equal(e.Priority)
+	})
+	match(func(p, e RuleModel) bool {
+		return true
+	})
+
+	return claimed
+}
+
+func toRuleModel(rule datafy.AutoscalingRule) RuleModel {
+	return RuleModel{
+		RuleId:      types.StringValue(rule.RuleId),
+		Name:        types.StringValue(rule.Name),
+		Description: types.StringValue(rule.Description),
+		Priority:    types.Int64Value(rule.Priority),
+		Active:      types.BoolValue(rule.Active),
+		Rule:        jsontypes.NewNormalizedValue(string(rule.Rule)),
+	}
+}
+
+func toRuleModels(rules []datafy.AutoscalingRule) []RuleModel {
+	res := make([]RuleModel, 0, len(rules))
+	for _, rule := range rules {
+		res = append(res, toRuleModel(rule))
+	}
+	return res
+}
+
+// rulesOverlap reports whether two active rules can match the same volume.
+// Rules outside the documented JsonLogic subset are never reported.
+func rulesOverlap(a, b RuleModel) bool {
+	if !a.Active.ValueBool() || !b.Active.ValueBool() || a.Rule.IsUnknown() || b.Rule.IsUnknown() {
+		return false
+	}
+	overlap, err := rulelogic.Overlap(json.RawMessage(a.Rule.ValueString()), json.RawMessage(b.Rule.ValueString()))
+	return err == nil && overlap
+}
+
+func ruleLabel(rules []RuleModel, i int) string {
+	if id := rules[i].RuleId; !id.IsUnknown() && !id.IsNull() {
+		return fmt.Sprintf("rules[%d] (%s)", i, id.ValueString())
+	}
+	return fmt.Sprintf("rules[%d]", i)
+}
+
+// configuredPriority returns the priority to send to the API, or nil to let
+// Datafy assign one. ModifyPlan plans the priority of a rule without a
+// configured one as unknown, for which ValueInt64Pointer would return 0.
+func configuredPriority(priority types.Int64) *int64 {
+	if priority.IsNull() || priority.IsUnknown() {
+		return nil
+	}
+	return priority.ValueInt64Pointer()
+}
+
+// ruleUnchanged reports whether the remote rule already matches the desired one.
+func ruleUnchanged(current datafy.AutoscalingRule, desired RuleModel) bool {
+	if p := configuredPriority(desired.Priority); p != nil && *p != current.Priority {
+		return false
+	}
+	return current.Name == desired.Name.ValueString() &&
+		current.Description == desired.Description.ValueString() &&
+		current.Active == desired.Active.ValueBool() &&
+		ruleEqual(string(current.Rule), desired.Rule.ValueString())
+}
+
+// ruleEqual reports whether two JSON rule documents are semantically equal.
+func ruleEqual(a, b string) bool {
+	var av, bv interface{}
+	if err := json.Unmarshal([]byte(a), &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bv); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
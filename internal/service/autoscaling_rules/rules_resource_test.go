@@ -0,0 +1,229 @@
+package autoscaling_rules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRule(ruleId, name string, priority int64, rule string) RuleModel {
+	m := RuleModel{
+		RuleId:      types.StringUnknown(),
+		Name:        types.StringValue(name),
+		Description: types.StringValue(""),
+		Priority:    types.Int64Unknown(),
+		Active:      types.BoolValue(true),
+		Rule:        jsontypes.NewNormalizedValue(rule),
+	}
+	if ruleId != "" {
+		m.RuleId = types.StringValue(ruleId)
+	}
+	if priority != 0 {
+		m.Priority = types.Int64Value(priority)
+	}
+	return m
+}
+
+func ruleIds(rules []RuleModel) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.RuleId.ValueString())
+	}
+	return ids
+}
+
+const (
+	clusterRule   = `{"==":[{"var":"cluster_name"},"prod"]}`
+	nodeGroupRule = `{"==":[{"var":"node_group_name"},"workers"]}`
+	editedRule    = `{"==":[{"var":"node_group_name"},"batch"]}`
+)
+
+func TestMatchRules_reorder(t *testing.T) {
+	existing := []RuleModel{
+		testRule("rule-1", "", 1, clusterRule),
+		testRule("rule-2", "", 2, nodeGroupRule),
+	}
+	planned := []RuleModel{
+		testRule("", "", 0, nodeGroupRule),
+		testRule("", "", 0, clusterRule),
+	}
+
+	claimed := matchRules(planned, existing)
+
+	assert.Equal(t, []string{"rule-2", "rule-1"}, ruleIds(planned))
+	assert.Equal(t, []bool{true, true}, claimed)
+}
+
+func TestMatchRules_reorderAndEditByName(t *testing.T) {
+	existing := []RuleModel{
+		testRule("rule-1", "clusters", 0, clusterRule),
+		testRule("rule-2", "node-groups", 0, nodeGroupRule),
+	}
+	planned := []RuleModel{
+		testRule("", "node-groups", 0, editedRule),
+		testRule("", "clusters", 0, `{"==":[{"var":"cluster_name"},"staging"]}`),
+	}
+
+	matchRules(planned, existing)
+
+	// Pairing by position would update rule-1 with the node group rule.
+	assert.Equal(t, []string{"rule-2", "rule-1"}, ruleIds(planned))
+}
+
+func TestMatchRules_reorderAndEditByPriority(t *testing.T) {
+	existing := []RuleModel{
+		testRule("rule-1", "", 10, clusterRule),
+		testRule("rule-2", "", 20, nodeGroupRule),
+	}
+	planned := []RuleModel{
+		testRule("", "", 20, editedRule),
+		testRule("", "", 10, `{"==":[{"var":"cluster_name"},"staging"]}`),
+	}
+
+	matchRules(planned, existing)
+
+	assert.Equal(t, []string{"rule-2", "rule-1"}, ruleIds(planned))
+}
+
+func TestMatchRules_reorderAndEditWithoutIdentity(t *testing.T) {
+	existing := []RuleModel{
+		testRule("rule-1", "", 1, clusterRule),
+		testRule("rule-2", "", 2, nodeGroupRule),
+	}
+	planned := []RuleModel{
+		testRule("", "", 0, editedRule),
+		testRule("", "", 0, clusterRule),
+	}
+
+	claimed := matchRules(planned, existing)
+
+	// The edited rule updates the rule that is left rather than replacing it.
+	assert.Equal(t, []string{"rule-2", "rule-1"}, ruleIds(planned))
+	assert.Equal(t, []bool{true, true}, claimed)
+}
+
+func TestMatchRules_removed(t *testing.T) {
+	existing := []RuleModel{
+		testRule("rule-1", "", 1, clusterRule),
+		testRule("rule-2", "", 2, nodeGroupRule),
+		testRule("rule-outside", "", 3, editedRule),
+	}
+	planned := []RuleModel{
+		testRule("", "", 0, clusterRule),
+		testRule("", "", 0, nodeGroupRule),
+	}
+
+	claimed := matchRules(planned, existing)
+
+	assert.Equal(t, []string{"rule-1", "rule-2"}, ruleIds(planned))
+	assert.Equal(t, []bool{true, true, false}, claimed)
+}
+
+func TestModifyPlan_outsideRuleWarning(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&Resource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, state.Set(ctx, &ResourceModel{
+		AccountId: types.StringValue("acc-123"),
+		Rules: []RuleModel{
+			testRule("rule-1", "", 1, clusterRule),
+			testRule("rule-outside", "", 2, nodeGroupRule),
+		},
+	}).HasError())
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, plan.Set(ctx, &ResourceModel{
+		AccountId: types.StringValue("acc-123"),
+		Rules:     []RuleModel{testRule("", "", 0, clusterRule)},
+	}).HasError())
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: plan.Raw},
+		Plan:   plan,
+		State:  state,
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	(&Resource{}).ModifyPlan(ctx, req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics, diag.NewWarningDiagnostic(
+		"Autoscaling rules will be deleted",
+		"The following autoscaling rules of account acc-123 are not in the configuration and will be deleted: rule-outside",
+	))
+
+	var planned ResourceModel
+	require.False(t, resp.Plan.Get(ctx, &planned).HasError())
+	assert.Equal(t, []string{"rule-1"}, ruleIds(planned.Rules))
+}
+
+func TestConfiguredPriority(t *testing.T) {
+	assert.Nil(t, configuredPriority(types.Int64Unknown()))
+	assert.Nil(t, configuredPriority(types.Int64Null()))
+	assert.Equal(t, int64(0), *configuredPriority(types.Int64Value(0)))
+	assert.Equal(t, int64(10), *configuredPriority(types.Int64Value(10)))
+}
+
+func TestRuleUnchanged_unconfiguredPriority(t *testing.T) {
+	current := datafy.AutoscalingRule{RuleId: "rule-1", Priority: 7, Active: true, Rule: json.RawMessage(clusterRule)}
+
+	assert.True(t, ruleUnchanged(current, testRule("rule-1", "", 0, clusterRule)))
+	assert.False(t, ruleUnchanged(current, testRule("rule-1", "", 3, clusterRule)))
+}
+
+func TestReconcile_updateKeepsSchedule(t *testing.T) {
+	existing := datafy.AutoscalingRule{
+		AccountId: "acc-123",
+		RuleId:    "rule-1",
+		Priority:  1,
+		Active:    true,
+		Schedule:  &datafy.AutoscalingRuleSchedule{Cron: "0 8 * * 1-5", DurationMinutes: 600, Timezone: "UTC"},
+		Rule:      json.RawMessage(clusterRule),
+	}
+
+	var gotBody map[string]json.RawMessage
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode([]datafy.AutoscalingRule{existing})
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			updated := existing
+			updated.Rule = json.RawMessage(editedRule)
+			_ = json.NewEncoder(w).Encode(updated)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	r := &Resource{client: datafy.NewClient("dummy", ts.URL)}
+	rules, diags := r.reconcile(context.Background(), "acc-123", []RuleModel{
+		testRule("rule-1", "", 1, editedRule),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	// The plural resource does not manage schedules, so the update must not
+	// remove the one the rule has.
+	require.NotNil(t, gotBody)
+	assert.NotContains(t, gotBody, "schedule")
+	assert.Equal(t, []string{"rule-1"}, ruleIds(rules))
+}
@@ -0,0 +1,116 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSourceWithConfigure = &DataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client *datafy.Client
+}
+
+type DataSourceModel struct {
+	AccountId       types.String      `tfsdk:"account_id"`
+	ParentAccountId types.String      `tfsdk:"parent_account_id"`
+	TokenId         types.String      `tfsdk:"token_id"`
+	RoleIds         types.List        `tfsdk:"role_ids"`
+	Expires         timetypes.RFC3339 `tfsdk:"expires"`
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_caller_identity"
+}
+
+func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the identity of the token the provider authenticates with.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account the token belongs to.",
+				Computed:    true,
+			},
+			"parent_account_id": schema.StringAttribute{
+				Description: "The unique identifier of the parent of the token's account.",
+				Computed:    true,
+			},
+			"token_id": schema.StringAttribute{
+				Description: "The unique identifier of the token.",
+				Computed:    true,
+			},
+			"role_ids": schema.ListAttribute{
+				Description: "The list of role IDs associated with the token.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"expires": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "The timestamp when the token will expire, in RFC 3339 format. Null if the token does not expire.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan DataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gcir, err := d.client.GetCallerIdentity(ctx, &datafy.GetCallerIdentityRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error read caller identity",
+			"Could not read caller identity: "+err.Error(),
+		)
+		return
+	}
+
+	identity := gcir.CallerIdentity
+	plan.AccountId = types.StringValue(identity.AccountId)
+	plan.ParentAccountId = types.StringValue(identity.ParentAccountId)
+	plan.TokenId = types.StringValue(identity.TokenId)
+	roleIds, diags := types.ListValueFrom(ctx, types.StringType, identity.RoleIds)
+	resp.Diagnostics.Append(diags...)
+	plan.RoleIds = roleIds
+	plan.Expires = timetypes.NewRFC3339Null()
+	if identity.Expires != nil {
+		plan.Expires = timetypes.NewRFC3339TimeValue(*identity.Expires)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
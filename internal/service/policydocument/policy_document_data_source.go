@@ -0,0 +1,227 @@
+package policydocument
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/iamarn"
+	"github.com/datafy-io/terraform-provider-datafy/internal/iampolicy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSourceWithConfigure      = &DataSource{}
+	_ datasource.DataSourceWithValidateConfig = &DataSource{}
+)
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+type DataSource struct {
+	client *datafy.Client
+}
+
+type DataSourceModel struct {
+	AccountId             types.String `tfsdk:"account_id"`
+	ExternalId            types.String `tfsdk:"external_id"`
+	PrincipalArn          types.String `tfsdk:"principal_arn"`
+	Partition             types.String `tfsdk:"partition"`
+	FeatureSet            types.String `tfsdk:"feature_set"`
+	TrustPolicyJson       types.String `tfsdk:"trust_policy_json"`
+	PermissionsPolicyJson types.String `tfsdk:"permissions_policy_json"`
+	PolicyVersion         types.String `tfsdk:"policy_version"`
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_policy_document"
+}
+
+func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Renders the AWS IAM trust policy and permissions policy Datafy requires for the role associated with an account. The policies ship with the provider, so upgrading the provider picks up newly required actions.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account. Its external ID is used in the trust policy. Exactly one of `account_id` or `external_id` must be set.",
+				Optional:    true,
+			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID to require in the trust policy. Exactly one of `account_id` or `external_id` must be set. When `account_id` is set, this is the external ID of that account.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"principal_arn": schema.StringAttribute{
+				Description: "The ARN of the Datafy AWS principal allowed to assume the role. Defaults to the Datafy principal for `partition` that ships with the provider. Only set this to override it, for example with a principal listed in the [Datafy documentation](https://docs.datafy.io/set-up-and-installation/datafy-installation/permissions-configuration) that is newer than the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"partition": schema.StringAttribute{
+				Description: "The AWS partition of the role, which selects the default `principal_arn`. One of `aws`, `aws-cn` or `aws-us-gov`. Defaults to `aws`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"feature_set": schema.StringAttribute{
+				Description: "The Datafy features the permissions policy grants access to. One of `read_only` (discover and monitor instances and volumes) or `autoscaling` (also resize, replace and tag volumes). Defaults to `autoscaling`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"trust_policy_json": schema.StringAttribute{
+				Description: "The trust policy JSON, for the `assume_role_policy` of an `aws_iam_role`.",
+				Computed:    true,
+			},
+			"permissions_policy_json": schema.StringAttribute{
+				Description: "The permissions policy JSON, for the `policy` of an `aws_iam_policy` or `aws_iam_role_policy`.",
+				Computed:    true,
+			},
+			"policy_version": schema.StringAttribute{
+				Description: "The revision of the rendered policies. It changes whenever a provider release changes the required permissions.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config DataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.AccountId.IsUnknown() && !config.ExternalId.IsUnknown() && config.AccountId.IsNull() == config.ExternalId.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("account_id"),
+			"Invalid Attribute Combination",
+			"Exactly one of account_id or external_id must be set.",
+		)
+	}
+
+	if !config.Partition.IsNull() && !config.Partition.IsUnknown() && !slices.Contains(iamarn.Partitions, config.Partition.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("partition"),
+			"Invalid Partition",
+			fmt.Sprintf("partition must be one of %s, got %q.", strings.Join(iamarn.Partitions, ", "), config.Partition.ValueString()),
+		)
+	}
+
+	if !config.FeatureSet.IsNull() && !config.FeatureSet.IsUnknown() {
+		if _, err := iampolicy.PermissionsPolicy(iampolicy.FeatureSet(config.FeatureSet.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("feature_set"),
+				"Invalid Feature Set",
+				fmt.Sprintf("feature_set must be one of %s, got %q.", strings.Join(iampolicy.FeatureSets, ", "), config.FeatureSet.ValueString()),
+			)
+		}
+	}
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan DataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.AccountId.IsNull() {
+		gar, err := d.client.GetAccount(ctx, &datafy.GetAccountRequest{
+			AccountId: plan.AccountId.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error read account",
+				"Could not read account: "+err.Error(),
+			)
+			return
+		}
+		plan.ExternalId = types.StringValue(gar.Account.ExternalId)
+	}
+
+	if plan.FeatureSet.IsNull() {
+		plan.FeatureSet = types.StringValue(string(iampolicy.FeatureSetAutoscaling))
+	}
+
+	if plan.Partition.IsNull() {
+		plan.Partition = types.StringValue("aws")
+	}
+	if plan.PrincipalArn.IsNull() {
+		principal, err := iampolicy.Principal(plan.Partition.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("principal_arn"),
+				"Missing Principal ARN",
+				fmt.Sprintf("The provider does not ship a Datafy principal for this partition: %s. Set principal_arn to the Datafy principal listed in the Datafy documentation.", err.Error()),
+			)
+			return
+		}
+		plan.PrincipalArn = types.StringValue(principal)
+	}
+
+	trust, err := iampolicy.TrustPolicy(plan.PrincipalArn.ValueString(), plan.ExternalId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering trust policy",
+			"Could not render trust policy: "+err.Error(),
+		)
+		return
+	}
+	trustJson, err := trust.JSON()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering trust policy",
+			"Could not render trust policy: "+err.Error(),
+		)
+		return
+	}
+
+	permissions, err := iampolicy.PermissionsPolicy(iampolicy.FeatureSet(plan.FeatureSet.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering permissions policy",
+			"Could not render permissions policy: "+err.Error(),
+		)
+		return
+	}
+	permissionsJson, err := permissions.JSON()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering permissions policy",
+			"Could not render permissions policy: "+err.Error(),
+		)
+		return
+	}
+
+	plan.TrustPolicyJson = types.StringValue(trustJson)
+	plan.PermissionsPolicyJson = types.StringValue(permissionsJson)
+	plan.PolicyVersion = types.StringValue(iampolicy.Version)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
@@ -0,0 +1,408 @@
+package rolearn
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/iamarn"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/datafy-io/terraform-provider-datafy/internal/retry"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.ResourceWithConfigure      = &AssociationResource{}
+	_ resource.ResourceWithImportState    = &AssociationResource{}
+	_ resource.ResourceWithModifyPlan     = &AssociationResource{}
+	_ resource.ResourceWithValidateConfig = &AssociationResource{}
+)
+
+var awsAccountIdPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+func NewAssociationResource() resource.Resource {
+	return &AssociationResource{}
+}
+
+type AssociationResource struct {
+	client       *datafy.Client
+	providerData *providerdata.ProviderData
+}
+
+type AssociationResourceModel struct {
+	AccountId          types.String      `tfsdk:"account_id"`
+	AwsAccountId       types.String      `tfsdk:"aws_account_id"`
+	RoleArn            types.String      `tfsdk:"role_arn"`
+	SkipValidation     types.Bool        `tfsdk:"skip_validation"`
+	ExternalId         types.String      `tfsdk:"external_id"`
+	ValidationStatus   types.String      `tfsdk:"validation_status"`
+	LastValidatedAt    timetypes.RFC3339 `tfsdk:"last_validated_at"`
+	MissingPermissions types.List        `tfsdk:"missing_permissions"`
+	Timeouts           timeouts.Value    `tfsdk:"timeouts"`
+}
+
+func (m *AssociationResourceModel) setValidationState(s validationState) {
+	m.ValidationStatus = s.Status
+	m.LastValidatedAt = s.LastValidatedAt
+	m.MissingPermissions = s.MissingPermissions
+}
+
+func (r *AssociationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aws_account_association"
+}
+
+func (r *AssociationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Associates an AWS account with a Datafy account through an IAM role in that AWS account. A Datafy account can span several AWS accounts, with one association each.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The unique identifier of the Datafy account. Changing this forces a new association to be created. Defaults to the provider's `default_account_id`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"aws_account_id": schema.StringAttribute{
+				Description: "The 12-digit ID of the AWS account to associate. Changing this forces a new association to be created.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_arn": schema.StringAttribute{
+				Description: "The ARN of the IAM role Datafy assumes in the AWS account. The role must belong to `aws_account_id`.",
+				Required:    true,
+				Validators: []validator.String{
+					iamarn.RoleArnValidator(),
+				},
+			},
+			"skip_validation": schema.BoolAttribute{
+				Description: "Skip IAM role permission validation. When set to `true`, the association is saved without verifying that the role has the required permissions. Defaults to `false`. This is a write-only attribute that requires Terraform 1.11 or later: it is never stored in state, and changing it alone does not cause a diff, except that turning it off for an association saved without validation plans an update that validates the role.",
+				Optional:    true,
+				WriteOnly:   true,
+			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID Datafy passes as `sts:ExternalId` when assuming the role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validation_status": schema.StringAttribute{
+				Description: "The outcome of the last role validation: `valid`, `invalid`, or `skipped` when `skip_validation` is set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_validated_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "The timestamp of the last role validation, in RFC 3339 format. Empty if the role has never been validated.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"missing_permissions": schema.ListAttribute{
+				Description: "The IAM actions the role lacked at the last validation.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Update:            true,
+				CreateDescription: "How long to wait for the IAM role to pass validation on create, e.g. `10m`. Defaults to `5m`.",
+				UpdateDescription: "How long to wait for the IAM role to pass validation on update, e.g. `10m`. Defaults to `5m`.",
+			}),
+		},
+	}
+}
+
+func (r *AssociationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.Client
+	r.providerData = data
+}
+
+func (r *AssociationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config AssociationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.AwsAccountId.IsNull() || config.AwsAccountId.IsUnknown() {
+		return
+	}
+
+	if !awsAccountIdPattern.MatchString(config.AwsAccountId.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("aws_account_id"),
+			"Invalid AWS Account ID",
+			fmt.Sprintf("aws_account_id must be exactly 12 digits, got %q.", config.AwsAccountId.ValueString()),
+		)
+		return
+	}
+
+	if config.RoleArn.IsNull() || config.RoleArn.IsUnknown() {
+		return
+	}
+
+	// Malformed ARNs are reported by the attribute validator.
+	roleArn, err := iamarn.ParseRoleArn(config.RoleArn.ValueString())
+	if err == nil && roleArn.AccountId != config.AwsAccountId.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_arn"),
+			"IAM Role In Different AWS Account",
+			fmt.Sprintf("The role %s belongs to AWS account %s, but aws_account_id is %s.", roleArn, roleArn.AccountId, config.AwsAccountId.ValueString()),
+		)
+	}
+}
+
+func (r *AssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AssociationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultValidationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skipValidation, diags := configSkipValidation(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	association, err := r.createAssociation(ctx, &plan, skipValidation)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating aws account association",
+			"Could not create aws account association: "+validationErrorDetail(err, createTimeout),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setAssociation(ctx, &plan, association)...)
+	resp.Diagnostics.Append(setValidationSkipped(ctx, resp.Private, skipValidation)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AssociationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	laar, err := r.client.ListAwsAccountAssociations(ctx, &datafy.ListAwsAccountAssociationsRequest{
+		AccountId: state.AccountId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error read aws account association",
+			"Could not read aws account association: "+err.Error(),
+		)
+		return
+	}
+
+	for _, association := range laar.AwsAccountAssociations {
+		if association.AwsAccountId == state.AwsAccountId.ValueString() {
+			resp.Diagnostics.Append(r.setAssociation(ctx, &state, association)...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *AssociationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if providerdata.PlanAccountId(ctx, r.providerData, req, resp) {
+		resp.RequiresReplace.Append(path.Root("account_id"))
+	}
+
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state AssociationResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, revalidate, diags := planSkipValidation(ctx, req.Config, req.Private, state.ValidationStatus)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RoleArn.Equal(state.RoleArn) || revalidate {
+		plan.setValidationState(unknownValidationState())
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
+func (r *AssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AssociationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultValidationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skipValidation, diags := configSkipValidation(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Creating an association for an already associated AWS account replaces
+	// its role.
+	association, err := r.createAssociation(ctx, &plan, skipValidation)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error update aws account association",
+			"Could not update aws account association: "+validationErrorDetail(err, updateTimeout),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.setAssociation(ctx, &plan, association)...)
+	resp.Diagnostics.Append(setValidationSkipped(ctx, resp.Private, skipValidation)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AssociationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format \"account_id:aws_account_id\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("aws_account_id"), parts[1])...)
+}
+
+func (r *AssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AssociationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteAwsAccountAssociation(ctx, &datafy.DeleteAwsAccountAssociationRequest{
+		AccountId:    state.AccountId.ValueString(),
+		AwsAccountId: state.AwsAccountId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error delete aws account association",
+			"Could not delete aws account association: "+err.Error(),
+		)
+		return
+	}
+}
+
+// createAssociation creates or replaces the association, retrying validation
+// failures until ctx is done since a newly created IAM role takes a while to
+// become assumable.
+func (r *AssociationResource) createAssociation(ctx context.Context, plan *AssociationResourceModel, skipValidation bool) (datafy.AwsAccountAssociation, error) {
+	externalId, err := accountExternalId(ctx, r.client, plan.AccountId.ValueString())
+	if err != nil {
+		return datafy.AwsAccountAssociation{}, fmt.Errorf("reading account external id: %w", err)
+	}
+
+	var caar *datafy.CreateAwsAccountAssociationResponse
+	err = retry.Do(ctx, retry.DefaultBackoff, datafy.IsRoleValidationError, func(ctx context.Context) error {
+		var err error
+		caar, err = r.client.CreateAwsAccountAssociation(ctx, &datafy.CreateAwsAccountAssociationRequest{
+			AccountId:      plan.AccountId.ValueString(),
+			AwsAccountId:   plan.AwsAccountId.ValueString(),
+			RoleArn:        plan.RoleArn.ValueString(),
+			ExternalId:     externalId,
+			SkipValidation: skipValidation,
+		})
+		return err
+	})
+	if err != nil {
+		return datafy.AwsAccountAssociation{}, err
+	}
+
+	if caar.AwsAccountAssociation.ExternalId == "" {
+		caar.AwsAccountAssociation.ExternalId = externalId
+	}
+	return caar.AwsAccountAssociation, nil
+}
+
+func (r *AssociationResource) setAssociation(ctx context.Context, m *AssociationResourceModel, a datafy.AwsAccountAssociation) diag.Diagnostics {
+	if a.RoleArn != "" {
+		m.RoleArn = types.StringValue(a.RoleArn)
+	}
+	m.ExternalId = types.StringValue(a.ExternalId)
+
+	validation, diags := flattenValidationState(ctx, a.RoleValidation)
+	m.setValidationState(validation)
+	return diags
+}
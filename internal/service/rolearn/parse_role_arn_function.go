@@ -0,0 +1,74 @@
+package rolearn
+
+import (
+	"context"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/iamarn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseRoleArnFunction{}
+
+func NewParseRoleArnFunction() function.Function {
+	return &ParseRoleArnFunction{}
+}
+
+type ParseRoleArnFunction struct{}
+
+var parsedRoleArnAttrTypes = map[string]attr.Type{
+	"partition":  types.StringType,
+	"account_id": types.StringType,
+	"path":       types.StringType,
+	"name":       types.StringType,
+}
+
+func (f *ParseRoleArnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_role_arn"
+}
+
+func (f *ParseRoleArnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse an AWS IAM role ARN.",
+		Description: "Parses an AWS IAM role ARN in the `aws`, `aws-cn` or `aws-us-gov` partition and returns an object with its `partition`, `account_id`, `path` and `name`. Fails if the ARN is not a valid IAM role ARN.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "arn",
+				Description: "The IAM role ARN to parse, e.g. `arn:aws:iam::123456789012:role/DatafyRole`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedRoleArnAttrTypes,
+		},
+	}
+}
+
+func (f *ParseRoleArnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var arn string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &arn))
+	if resp.Error != nil {
+		return
+	}
+
+	roleArn, err := iamarn.ParseRoleArn(arn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(parsedRoleArnAttrTypes, map[string]attr.Value{
+		"partition":  types.StringValue(roleArn.Partition),
+		"account_id": types.StringValue(roleArn.AccountId),
+		"path":       types.StringValue(roleArn.Path),
+		"name":       types.StringValue(roleArn.Name),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/iamarn"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,8 +26,12 @@ type DataSource struct {
 }
 
 type DataSourceModel struct {
-	AccountId types.String `tfsdk:"account_id"`
-	Arn       types.String `tfsdk:"arn"`
+	AccountId          types.String      `tfsdk:"account_id"`
+	Arn                types.String      `tfsdk:"arn"`
+	ExternalId         types.String      `tfsdk:"external_id"`
+	ValidationStatus   types.String      `tfsdk:"validation_status"`
+	LastValidatedAt    timetypes.RFC3339 `tfsdk:"last_validated_at"`
+	MissingPermissions types.List        `tfsdk:"missing_permissions"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -42,6 +50,24 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Description: "The Amazon Resource Name (ARN) of the IAM role associated with the account.",
 				Computed:    true,
 			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID Datafy passes as `sts:ExternalId` when assuming the role.",
+				Computed:    true,
+			},
+			"validation_status": schema.StringAttribute{
+				Description: "The outcome of the last role validation: `valid`, `invalid`, or `skipped` when validation was skipped.",
+				Computed:    true,
+			},
+			"last_validated_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "The timestamp of the last role validation, in RFC 3339 format. Empty if the role has never been validated.",
+				Computed:    true,
+			},
+			"missing_permissions": schema.ListAttribute{
+				Description: "The IAM actions the role lacked at the last validation.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -52,17 +78,17 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*datafy.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *datafy.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,7 +110,22 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
+	if _, err := iamarn.ParseRoleArn(garar.AccountRoleArn.RoleArn); err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("arn"),
+			"Invalid IAM Role ARN",
+			fmt.Sprintf("The role ARN associated with account %s is not a valid IAM role ARN: %s", plan.AccountId.ValueString(), err.Error()),
+		)
+	}
+
 	plan.Arn = types.StringValue(garar.AccountRoleArn.RoleArn)
+	plan.ExternalId = types.StringValue(garar.AccountRoleArn.ExternalId)
+
+	validation, diags := flattenValidationState(ctx, garar.AccountRoleArn.RoleValidation)
+	resp.Diagnostics.Append(diags...)
+	plan.ValidationStatus = validation.Status
+	plan.LastValidatedAt = validation.LastValidatedAt
+	plan.MissingPermissions = validation.MissingPermissions
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -2,12 +2,24 @@ package rolearn
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/datafy-io/terraform-provider-datafy/internal/iamarn"
+	"github.com/datafy-io/terraform-provider-datafy/internal/providerdata"
+	"github.com/datafy-io/terraform-provider-datafy/internal/retry"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -15,20 +27,38 @@ import (
 var (
 	_ resource.ResourceWithConfigure   = &Resource{}
 	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
 )
 
+// defaultValidationTimeout bounds how long Create and Update wait for a newly
+// created IAM role to become assumable and pass validation.
+const defaultValidationTimeout = 5 * time.Minute
+
 func NewResource() resource.Resource {
 	return &Resource{}
 }
 
 type Resource struct {
-	client *datafy.Client
+	client       *datafy.Client
+	providerData *providerdata.ProviderData
 }
 
 type ResourceModel struct {
-	AccountId      types.String `tfsdk:"account_id"`
-	Arn            types.String `tfsdk:"arn"`
-	SkipValidation types.Bool   `tfsdk:"skip_validation"`
+	AccountId          types.String      `tfsdk:"account_id"`
+	Arn                types.String      `tfsdk:"arn"`
+	SkipValidation     types.Bool        `tfsdk:"skip_validation"`
+	ExternalId         types.String      `tfsdk:"external_id"`
+	RevalidateTriggers types.Map         `tfsdk:"revalidate_triggers"`
+	ValidationStatus   types.String      `tfsdk:"validation_status"`
+	LastValidatedAt    timetypes.RFC3339 `tfsdk:"last_validated_at"`
+	MissingPermissions types.List        `tfsdk:"missing_permissions"`
+	Timeouts           timeouts.Value    `tfsdk:"timeouts"`
+}
+
+func (m *ResourceModel) setValidationState(s validationState) {
+	m.ValidationStatus = s.Status
+	m.LastValidatedAt = s.LastValidatedAt
+	m.MissingPermissions = s.MissingPermissions
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -40,17 +70,68 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 		Description: "Manages the AWS IAM role ARN associated with a Datafy account. This role grants Datafy permission to access and manage AWS resources on your behalf. For information about required permissions, see the [Datafy documentation](https://docs.datafy.io/set-up-and-installation/datafy-installation/permissions-configuration).",
 		Attributes: map[string]schema.Attribute{
 			"account_id": schema.StringAttribute{
-				Description: "The unique identifier of the Datafy account to associate the IAM role with.",
-				Required:    true,
+				Description: "The unique identifier of the Datafy account to associate the IAM role with. Defaults to the provider's `default_account_id`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"arn": schema.StringAttribute{
-				Description: "The Amazon Resource Name (ARN) of the IAM role that Datafy will assume. Must be a valid IAM role ARN in the format `arn:aws:iam::<account-id>:role/<role-name>`.",
+				Description: "The Amazon Resource Name (ARN) of the IAM role that Datafy will assume. Must be a valid IAM role ARN in the format `arn:<partition>:iam::<account-id>:role/<role-name>`, where `<partition>` is one of `aws`, `aws-cn` or `aws-us-gov`. The ARN is validated during `terraform plan`, also when `skip_validation` is set.",
 				Required:    true,
+				Validators: []validator.String{
+					iamarn.RoleArnValidator(),
+				},
 			},
 			"skip_validation": schema.BoolAttribute{
-				Description: "Skip IAM role permission validation. When set to `true`, the role ARN will be saved without verifying that the role has the required permissions. Defaults to `false`.",
+				Description: "Skip IAM role permission validation. When set to `true`, the role ARN will be saved without verifying that the role has the required permissions. Defaults to `false`. This is a write-only attribute that requires Terraform 1.11 or later: it is never stored in state, and changing it alone does not cause a diff, except that turning it off for a role saved without validation plans an update that validates the role.",
 				Optional:    true,
+				WriteOnly:   true,
+			},
+			"external_id": schema.StringAttribute{
+				Description: "The external ID Datafy passes as `sts:ExternalId` when assuming the role. The role's trust policy must require this value. It is sent with the role ARN and verified during role validation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revalidate_triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, make Datafy validate the role again on the next apply, e.g. a hash of the role's permissions policy. Validation is skipped when `skip_validation` is set.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"validation_status": schema.StringAttribute{
+				Description: "The outcome of the last role validation: `valid`, `invalid`, or `skipped` when `skip_validation` is set. When Datafy reports `invalid` and `skip_validation` is not set, the next plan revalidates the role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_validated_at": schema.StringAttribute{
+				CustomType:  timetypes.RFC3339Type{},
+				Description: "The timestamp of the last role validation, in RFC 3339 format. Empty if the role has never been validated.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"missing_permissions": schema.ListAttribute{
+				Description: "The IAM actions the role lacked at the last validation.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Update:            true,
+				CreateDescription: "How long to wait for the IAM role to pass validation on create, e.g. `10m`. Defaults to `5m`.",
+				UpdateDescription: "How long to wait for the IAM role to pass validation on update, e.g. `10m`. Defaults to `5m`.",
+			}),
 		},
 	}
 }
@@ -61,17 +142,18 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*datafy.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *datafy.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.providerData = data
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -82,19 +164,56 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	_, err := r.client.CreateAccountRoleArn(ctx, &datafy.CreateAccountRoleArnRequest{
-		AccountId:      plan.AccountId.ValueString(),
-		Arn:            plan.Arn.ValueString(),
-		SkipValidation: plan.SkipValidation.ValueBool(),
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultValidationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skipValidation, diags := configSkipValidation(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	externalId, err := accountExternalId(ctx, r.client, plan.AccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating account role arn",
+			"Could not read account external id: "+err.Error(),
+		)
+		return
+	}
+
+	// A newly created IAM role takes a while to become assumable, so
+	// validation failures are retried until the create timeout.
+	var caarr *datafy.CreateAccountRoleArnResponse
+	err = retry.Do(ctx, retry.DefaultBackoff, datafy.IsRoleValidationError, func(ctx context.Context) error {
+		var err error
+		caarr, err = r.client.CreateAccountRoleArn(ctx, &datafy.CreateAccountRoleArnRequest{
+			AccountId:      plan.AccountId.ValueString(),
+			Arn:            plan.Arn.ValueString(),
+			ExternalId:     externalId,
+			SkipValidation: skipValidation,
+		})
+		return err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating account role arn",
-			"Could not create account role arn: "+err.Error(),
+			"Could not create account role arn: "+validationErrorDetail(err, createTimeout),
 		)
 		return
 	}
 
+	plan.ExternalId = types.StringValue(firstNonEmpty(caarr.AccountRoleArn.ExternalId, externalId))
+	validation, diags := flattenValidationState(ctx, caarr.AccountRoleArn.RoleValidation)
+	resp.Diagnostics.Append(diags...)
+	plan.setValidationState(validation)
+	resp.Diagnostics.Append(setValidationSkipped(ctx, resp.Private, skipValidation)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -118,10 +237,65 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	}
 
 	state.Arn = types.StringValue(garar.AccountRoleArn.RoleArn)
+	if garar.AccountRoleArn.ExternalId != "" || state.ExternalId.IsNull() {
+		state.ExternalId = types.StringValue(garar.AccountRoleArn.ExternalId)
+	}
+	validation, diags := flattenValidationState(ctx, garar.AccountRoleArn.RoleValidation)
+	resp.Diagnostics.Append(diags...)
+	state.setValidationState(validation)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	providerdata.PlanAccountId(ctx, r.providerData, req, resp)
+
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skipValidation, revalidate, diags := planSkipValidation(ctx, req.Config, req.Private, state.ValidationStatus)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Permission drift on the AWS side is surfaced as a planned update that
+	// validates the role again.
+	drifted := !skipValidation && state.ValidationStatus.ValueString() == datafy.RoleValidationStatusInvalid
+	if drifted {
+		var missing []string
+		resp.Diagnostics.Append(state.MissingPermissions.ElementsAs(ctx, &missing, false)...)
+		detail := fmt.Sprintf("Datafy can no longer validate the IAM role %s for account %s.", state.Arn.ValueString(), state.AccountId.ValueString())
+		if len(missing) > 0 {
+			detail += " Missing permissions: " + strings.Join(missing, ", ") + "."
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("validation_status"),
+			"IAM Role Failed Validation",
+			detail+" The role is validated again on the next apply.",
+		)
+	}
+
+	// Turning skip_validation on has nothing to do until the next update.
+	if drifted ||
+		revalidate ||
+		!plan.Arn.Equal(state.Arn) ||
+		!plan.RevalidateTriggers.Equal(state.RevalidateTriggers) {
+		plan.setValidationState(unknownValidationState())
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan ResourceModel
 
@@ -130,19 +304,54 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	_, err := r.client.UpdateAccountRoleArn(ctx, &datafy.UpdateAccountRoleArnRequest{
-		AccountId:      plan.AccountId.ValueString(),
-		Arn:            plan.Arn.ValueString(),
-		SkipValidation: plan.SkipValidation.ValueBool(),
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultValidationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	skipValidation, diags := configSkipValidation(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	externalId, err := accountExternalId(ctx, r.client, plan.AccountId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error update account role arn",
+			"Could not read account external id: "+err.Error(),
+		)
+		return
+	}
+
+	var uaarr *datafy.UpdateAccountRoleArnResponse
+	err = retry.Do(ctx, retry.DefaultBackoff, datafy.IsRoleValidationError, func(ctx context.Context) error {
+		var err error
+		uaarr, err = r.client.UpdateAccountRoleArn(ctx, &datafy.UpdateAccountRoleArnRequest{
+			AccountId:      plan.AccountId.ValueString(),
+			Arn:            plan.Arn.ValueString(),
+			ExternalId:     externalId,
+			SkipValidation: skipValidation,
+		})
+		return err
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error update account role arn",
-			"Could not update account role arn: "+err.Error(),
+			"Could not update account role arn: "+validationErrorDetail(err, updateTimeout),
 		)
 		return
 	}
 
+	plan.ExternalId = types.StringValue(firstNonEmpty(uaarr.AccountRoleArn.ExternalId, externalId))
+	validation, diags := flattenValidationState(ctx, uaarr.AccountRoleArn.RoleValidation)
+	resp.Diagnostics.Append(diags...)
+	plan.setValidationState(validation)
+	resp.Diagnostics.Append(setValidationSkipped(ctx, resp.Private, skipValidation)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -169,3 +378,38 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 }
+
+// accountExternalId returns the external ID Datafy uses when assuming roles
+// for the account, so it can be sent along with the role ARN and verified.
+func accountExternalId(ctx context.Context, client *datafy.Client, accountId string) (string, error) {
+	gar, err := client.GetAccount(ctx, &datafy.GetAccountRequest{
+		AccountId: accountId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return gar.Account.ExternalId, nil
+}
+
+// validationErrorDetail explains a validation failure that persisted for the
+// whole timeout, so it is not mistaken for a one-off error.
+func validationErrorDetail(err error, timeout time.Duration) string {
+	var apiErr *datafy.APIError
+	if errors.As(err, &apiErr) && datafy.IsRoleValidationError(err) {
+		detail := fmt.Sprintf("the role did not pass validation within %s, increase the timeout or check the role's trust policy and permissions: %s", timeout, err.Error())
+		if len(apiErr.MissingPermissions) > 0 {
+			detail += ". Missing permissions: " + strings.Join(apiErr.MissingPermissions, ", ")
+		}
+		return detail
+	}
+	return err.Error()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package rolearn
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validationState is the outcome of the last role validation Datafy ran, as
+// exposed by the resource and data source.
+type validationState struct {
+	Status             types.String
+	LastValidatedAt    timetypes.RFC3339
+	MissingPermissions types.List
+}
+
+func flattenValidationState(ctx context.Context, a datafy.RoleValidation) (validationState, diag.Diagnostics) {
+	state := validationState{
+		Status:          types.StringValue(a.ValidationStatus),
+		LastValidatedAt: timetypes.NewRFC3339Null(),
+	}
+	if a.LastValidatedAt != nil {
+		state.LastValidatedAt = timetypes.NewRFC3339TimeValue(*a.LastValidatedAt)
+	}
+
+	missing := a.MissingPermissions
+	if missing == nil {
+		missing = []string{}
+	}
+	var diags diag.Diagnostics
+	state.MissingPermissions, diags = types.ListValueFrom(ctx, types.StringType, missing)
+
+	return state, diags
+}
+
+// privateKeySkipValidation records in private state whether the role was
+// last saved with skip_validation, since write-only attributes are never
+// stored in state.
+const privateKeySkipValidation = "skip_validation"
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// validationSkipped reports whether the role was last saved without
+// validation. Resources created by earlier provider versions or imported
+// have no private state, so the validation status reported by Datafy is used
+// instead.
+func validationSkipped(ctx context.Context, private privateStateGetter, status types.String) (bool, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateKeySkipValidation)
+	if len(value) > 0 {
+		return string(value) == "true", diags
+	}
+	return status.ValueString() == datafy.RoleValidationStatusSkipped, diags
+}
+
+func setValidationSkipped(ctx context.Context, private privateStateSetter, skipped bool) diag.Diagnostics {
+	return private.SetKey(ctx, privateKeySkipValidation, []byte(strconv.FormatBool(skipped)))
+}
+
+// configSkipValidation returns the configured value of the write-only
+// skip_validation attribute, which is always null in plan and state.
+func configSkipValidation(ctx context.Context, config tfsdk.Config) (bool, diag.Diagnostics) {
+	var skip types.Bool
+	diags := config.GetAttribute(ctx, path.Root("skip_validation"), &skip)
+	return skip.ValueBool(), diags
+}
+
+// planSkipValidation returns the configured skip_validation for an update of
+// a role whose last validation status in state is status, and reports
+// whether the update validates the role again because skip_validation was
+// turned off for a role saved without validation.
+func planSkipValidation(ctx context.Context, config tfsdk.Config, private privateStateGetter, status types.String) (skipValidation, revalidate bool, diags diag.Diagnostics) {
+	skipValidation, diags = configSkipValidation(ctx, config)
+	skipped, skippedDiags := validationSkipped(ctx, private, status)
+	diags.Append(skippedDiags...)
+	return skipValidation, skipped && !skipValidation, diags
+}
+
+// unknownValidationState is the planned validation state of a role that is
+// validated again on apply.
+func unknownValidationState() validationState {
+	return validationState{
+		Status:             types.StringUnknown(),
+		LastValidatedAt:    timetypes.NewRFC3339Unknown(),
+		MissingPermissions: types.ListUnknown(types.StringType),
+	}
+}
@@ -0,0 +1,121 @@
+package rolearn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-datafy/internal/datafy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenValidationState(t *testing.T) {
+	lastValidatedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	state, diags := flattenValidationState(context.Background(), datafy.RoleValidation{
+		ValidationStatus:   datafy.RoleValidationStatusInvalid,
+		LastValidatedAt:    &lastValidatedAt,
+		MissingPermissions: []string{"ec2:ModifyVolume"},
+	})
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "invalid", state.Status.ValueString())
+	assert.Equal(t, "2025-03-01T12:00:00Z", state.LastValidatedAt.ValueString())
+	assert.Len(t, state.MissingPermissions.Elements(), 1)
+}
+
+func TestFlattenValidationState_neverValidated(t *testing.T) {
+	state, diags := flattenValidationState(context.Background(), datafy.RoleValidation{
+		ValidationStatus: datafy.RoleValidationStatusSkipped,
+	})
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "skipped", state.Status.ValueString())
+	assert.True(t, state.LastValidatedAt.IsNull())
+	assert.False(t, state.MissingPermissions.IsNull())
+	assert.Empty(t, state.MissingPermissions.Elements())
+}
+
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestValidationSkipped(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+
+	// Without private state the status reported by Datafy is used.
+	skipped, _ := validationSkipped(ctx, private, types.StringValue(datafy.RoleValidationStatusSkipped))
+	assert.True(t, skipped)
+	skipped, _ = validationSkipped(ctx, private, types.StringValue(datafy.RoleValidationStatusValid))
+	assert.False(t, skipped)
+
+	setValidationSkipped(ctx, private, false)
+	skipped, _ = validationSkipped(ctx, private, types.StringValue(datafy.RoleValidationStatusSkipped))
+	assert.False(t, skipped)
+
+	setValidationSkipped(ctx, private, true)
+	skipped, _ = validationSkipped(ctx, private, types.StringValue(datafy.RoleValidationStatusValid))
+	assert.True(t, skipped)
+}
+
+func skipValidationConfig(skip *bool) tfsdk.Config {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"skip_validation": schema.BoolAttribute{Optional: true, WriteOnly: true},
+		},
+	}
+	value := tftypes.NewValue(tftypes.Bool, nil)
+	if skip != nil {
+		value = tftypes.NewValue(tftypes.Bool, *skip)
+	}
+	return tfsdk.Config{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), map[string]tftypes.Value{"skip_validation": value}),
+	}
+}
+
+func TestPlanSkipValidation(t *testing.T) {
+	ctx := context.Background()
+	skip, noSkip := true, false
+	saved := fakePrivateState{}
+	setValidationSkipped(ctx, saved, true)
+
+	cases := []struct {
+		name       string
+		config     tfsdk.Config
+		private    fakePrivateState
+		status     string
+		skip       bool
+		revalidate bool
+	}{
+		{"turned off", skipValidationConfig(&noSkip), saved, datafy.RoleValidationStatusSkipped, false, true},
+		{"removed", skipValidationConfig(nil), saved, datafy.RoleValidationStatusSkipped, false, true},
+		{"still on", skipValidationConfig(&skip), saved, datafy.RoleValidationStatusSkipped, true, false},
+		{"turned on", skipValidationConfig(&skip), fakePrivateState{}, datafy.RoleValidationStatusValid, true, false},
+		{"never on", skipValidationConfig(nil), fakePrivateState{}, datafy.RoleValidationStatusValid, false, false},
+		{"imported skipped", skipValidationConfig(nil), fakePrivateState{}, datafy.RoleValidationStatusSkipped, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			skipValidation, revalidate, diags := planSkipValidation(ctx, tc.config, tc.private, types.StringValue(tc.status))
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, tc.skip, skipValidation)
+			assert.Equal(t, tc.revalidate, revalidate)
+		})
+	}
+}